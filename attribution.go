@@ -0,0 +1,170 @@
+package pexels
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	htmltemplate "html/template"
+	"net/url"
+	"strings"
+	"text/template"
+)
+
+// Attribution is everything needed to credit the creator of a Photo or Video,
+// as Pexels asks you to.
+type Attribution struct {
+	// Kind is either "Photo" or "Video".
+	Kind string
+	// Name is the name of the photographer or videographer.
+	Name string
+	// ProfileURL is the Pexels profile of the creator.
+	ProfileURL string
+	// SourceURL is the Pexels page of the Photo or Video.
+	SourceURL string
+}
+
+// UTM are the campaign parameters added to the URLs of an Attribution by
+// WithUTM, every zero field is left out.
+type UTM struct {
+	Source   string
+	Medium   string
+	Campaign string
+	Content  string
+	Term     string
+}
+
+// Attribution returns the credits of the Photo.
+func (p Photo) Attribution() Attribution {
+	return Attribution{
+		Kind:       string(TypePhoto),
+		Name:       p.Photographer,
+		ProfileURL: p.PhotographerURL,
+		SourceURL:  p.URL,
+	}
+}
+
+// Attribution returns the credits of the Video.
+func (v Video) Attribution() Attribution {
+	return Attribution{
+		Kind:       string(TypeVideo),
+		Name:       v.User.Name,
+		ProfileURL: v.User.URL,
+		SourceURL:  v.URL,
+	}
+}
+
+// Attribution returns the credits found in the raw JSON, as far as they are
+// stored like they are for a Photo or Video.
+func (u UnknownMedia) Attribution() Attribution {
+	var data struct {
+		URL             string    `json:"url"`
+		Photographer    string    `json:"photographer"`
+		PhotographerURL string    `json:"photographer_url"` //nolint:tagliatelle
+		User            PexelUser `json:"user"`
+	}
+	_ = json.Unmarshal(u.Raw, &data)
+	a := Attribution{Kind: u.Type, SourceURL: data.URL}
+	a.Name, a.ProfileURL = data.Photographer, data.PhotographerURL
+	if a.Name == "" {
+		a.Name, a.ProfileURL = data.User.Name, data.User.URL
+	}
+	return a
+}
+
+// WithUTM returns a copy of the Attribution with the UTM parameters added to
+// the ProfileURL and SourceURL.
+func (a Attribution) WithUTM(u UTM) Attribution {
+	a.ProfileURL = addUTM(a.ProfileURL, u)
+	a.SourceURL = addUTM(a.SourceURL, u)
+	return a
+}
+
+func addUTM(rawURL string, u UTM) string {
+	parsed, err := url.Parse(rawURL)
+	if rawURL == "" || err != nil {
+		return rawURL
+	}
+	q := parsed.Query()
+	setString(q, "utm_source", u.Source)
+	setString(q, "utm_medium", u.Medium)
+	setString(q, "utm_campaign", u.Campaign)
+	setString(q, "utm_content", u.Content)
+	setString(q, "utm_term", u.Term)
+	parsed.RawQuery = q.Encode()
+	return parsed.String()
+}
+
+var (
+	attributionText = template.Must(template.New("text").Parse(
+		`{{.Kind}} by {{.Name}} on Pexels: {{.SourceURL}}`))
+	attributionMarkdown = template.Must(template.New("markdown").Funcs(
+		template.FuncMap{"md": escapeMarkdown, "mdurl": escapeMarkdownURL},
+	).Parse(`{{.Kind}} by [{{md .Name}}]({{mdurl .ProfileURL}}) ` +
+		`on [Pexels]({{mdurl .SourceURL}})`))
+	attributionHTML = htmltemplate.Must(htmltemplate.New("html").Parse(
+		`{{.Kind}} by <a href="{{.ProfileURL}}">{{.Name}}</a> on ` +
+			`<a href="{{.SourceURL}}">Pexels</a>`))
+)
+
+// Text returns the credits as plain text, e.g.
+// "Photo by Jane Doe on Pexels: https://www.pexels.com/photo/...".
+func (a Attribution) Text() string {
+	s, _ := a.Render(attributionText)
+	return s
+}
+
+// Markdown returns the credits as Markdown linking to both the creator and
+// the source page.
+func (a Attribution) Markdown() string {
+	s, _ := a.Render(attributionMarkdown)
+	return s
+}
+
+// HTML returns the credits as HTML linking to both the creator and the source
+// page, safe to use as is within an html/template.
+func (a Attribution) HTML() htmltemplate.HTML {
+	h, _ := a.RenderHTML(attributionHTML)
+	return h
+}
+
+// Render executes tmpl with the Attribution, for when the credits should look
+// different than Text or Markdown make them.
+func (a Attribution) Render(tmpl *template.Template) (string, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, a); err != nil {
+		return "", fmt.Errorf(wrapFmt, err)
+	}
+	return buf.String(), nil
+}
+
+// RenderHTML executes tmpl with the Attribution, for when the credits should
+// look different than HTML makes them.
+func (a Attribution) RenderHTML(
+	tmpl *htmltemplate.Template,
+) (htmltemplate.HTML, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, a); err != nil {
+		return "", fmt.Errorf(wrapFmt, err)
+	}
+	return htmltemplate.HTML(buf.String()), nil //nolint:gosec
+}
+
+// markdownEscaper escapes everything in a name that Markdown would turn into
+// formatting, links or raw HTML.
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, `[`, `\[`, `]`, `\]`, `*`, `\*`, `_`, `\_`, "`", "\\`",
+	`<`, `\<`, `>`, `\>`,
+)
+
+func escapeMarkdown(s string) string {
+	return markdownEscaper.Replace(s)
+}
+
+// markdownURLEscaper percent-encodes what would end a Markdown link target.
+var markdownURLEscaper = strings.NewReplacer(
+	`(`, `%28`, `)`, `%29`, ` `, `%20`, `<`, `%3C`, `>`, `%3E`,
+)
+
+func escapeMarkdownURL(s string) string {
+	return markdownURLEscaper.Replace(s)
+}
@@ -0,0 +1,61 @@
+package pexels_test
+
+import (
+	"testing"
+
+	"github.com/j-mnr/pexels-go"
+	"github.com/matryer/is"
+)
+
+func TestAttributionMarkdown(t *testing.T) {
+	tests := map[string]struct {
+		attribution pexels.Attribution
+		want        string
+	}{
+		"plain": {
+			pexels.Attribution{
+				Kind:       "Photo",
+				Name:       "Jane Doe",
+				ProfileURL: "https://www.pexels.com/@jane",
+				SourceURL:  "https://www.pexels.com/photo/ocean-1/",
+			},
+			"Photo by [Jane Doe](https://www.pexels.com/@jane) " +
+				"on [Pexels](https://www.pexels.com/photo/ocean-1/)",
+		},
+		"formatting in the name": {
+			pexels.Attribution{
+				Kind:       "Video",
+				Name:       "*J_[x]*",
+				ProfileURL: "https://www.pexels.com/@j",
+				SourceURL:  "https://www.pexels.com/video/1/",
+			},
+			`Video by [\*J\_\[x\]\*](https://www.pexels.com/@j) ` +
+				"on [Pexels](https://www.pexels.com/video/1/)",
+		},
+		"raw HTML in the name": {
+			pexels.Attribution{
+				Kind:       "Photo",
+				Name:       "<img src=x onerror=alert(1)>",
+				ProfileURL: "https://www.pexels.com/@x",
+				SourceURL:  "https://www.pexels.com/photo/1/",
+			},
+			`Photo by [\<img src=x onerror=alert(1)\>](https://www.pexels.com/@x) ` +
+				"on [Pexels](https://www.pexels.com/photo/1/)",
+		},
+		"parentheses in the links": {
+			pexels.Attribution{
+				Kind:       "Photo",
+				Name:       "Jane",
+				ProfileURL: "https://www.pexels.com/@jane)[x](javascript:alert(1)",
+				SourceURL:  "https://www.pexels.com/photo/a (b)/",
+			},
+			"Photo by [Jane](https://www.pexels.com/@jane%29[x]%28javascript:alert%281%29) " +
+				"on [Pexels](https://www.pexels.com/photo/a%20%28b%29/)",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			is.New(t).Equal(tt.attribution.Markdown(), tt.want)
+		})
+	}
+}
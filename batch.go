@@ -0,0 +1,83 @@
+package pexels
+
+import (
+	"context"
+	"sync"
+)
+
+// defaultBatchWorkers is how many requests GetPhotos and GetVideos make at
+// once unless changed with WithBatchWorkers.
+const defaultBatchWorkers = 4
+
+// WithBatchWorkers sets how many requests GetPhotos and GetVideos make at
+// once. Every request still goes through the rate limiter, if any.
+func WithBatchWorkers(n int) Option {
+	return func(cl *Client) { cl.batchWorkers = n }
+}
+
+// PhotoResult is the outcome of fetching a single Photo with GetPhotos. Err
+// matches ErrNotFound if there is no Photo with the ID.
+type PhotoResult struct {
+	ID uint64
+	PhotoResponse
+	Err error
+}
+
+// VideoResult is the outcome of fetching a single Video with GetVideos. Err
+// matches ErrNotFound if there is no Video with the ID.
+type VideoResult struct {
+	ID uint64
+	VideoResponse
+	Err error
+}
+
+// GetPhotos fetches every photo in ids concurrently and returns a result for
+// each of them in the same order as ids.
+func (c *Client) GetPhotos(ctx context.Context, ids []uint64) []PhotoResult {
+	results := make([]PhotoResult, len(ids))
+	c.batch(ctx, len(ids), func(ctx context.Context, i int) {
+		pr, err := c.GetPhoto(ctx, ids[i])
+		results[i] = PhotoResult{ID: ids[i], PhotoResponse: pr, Err: err}
+	})
+	return results
+}
+
+// GetVideos fetches every video in ids concurrently and returns a result for
+// each of them in the same order as ids.
+func (c *Client) GetVideos(ctx context.Context, ids []uint64) []VideoResult {
+	results := make([]VideoResult, len(ids))
+	c.batch(ctx, len(ids), func(ctx context.Context, i int) {
+		vr, err := c.GetVideo(ctx, ids[i])
+		results[i] = VideoResult{ID: ids[i], VideoResponse: vr, Err: err}
+	})
+	return results
+}
+
+// batch calls fn for every index up to n using at most the Client's number of
+// batch workers at once.
+func (c *Client) batch(
+	ctx context.Context, n int, fn func(ctx context.Context, i int),
+) {
+	workers := c.batchWorkers
+	if workers <= 0 {
+		workers = defaultBatchWorkers
+	}
+	workers = min(workers, n)
+
+	next := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				fn(ctx, i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+}
@@ -0,0 +1,112 @@
+package pexels_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/j-mnr/pexels-go"
+	"github.com/j-mnr/pexels-go/pexelstest"
+	"github.com/matryer/is"
+)
+
+func TestGetPhotos(t *testing.T) {
+	is := is.New(t)
+	srv := pexelstest.NewServer()
+	defer srv.Close()
+	c, err := srv.NewClient()
+	is.NoErr(err)
+
+	var ids []uint64
+	for i := 9; i >= 0; i-- {
+		ids = append(ids, srv.Photos[i].ID)
+	}
+	ids[4] = 1 // no such photo
+
+	results := c.GetPhotos(context.Background(), ids)
+	is.Equal(len(results), len(ids))
+	for i, r := range results {
+		is.Equal(r.ID, ids[i])
+		if i == 4 {
+			is.True(errors.Is(r.Err, pexels.ErrNotFound))
+			continue
+		}
+		is.NoErr(r.Err)
+		is.Equal(r.Photo.ID, ids[i])
+	}
+}
+
+func TestGetVideos(t *testing.T) {
+	is := is.New(t)
+	srv := pexelstest.NewServer()
+	defer srv.Close()
+	c, err := srv.NewClient()
+	is.NoErr(err)
+
+	ids := []uint64{srv.Videos[5].ID, 1, srv.Videos[0].ID, srv.Videos[5].ID}
+	results := c.GetVideos(context.Background(), ids)
+	is.Equal(len(results), len(ids))
+	for i, r := range results {
+		is.Equal(r.ID, ids[i])
+		if ids[i] == 1 {
+			is.True(errors.Is(r.Err, pexels.ErrNotFound))
+			continue
+		}
+		is.NoErr(r.Err)
+		is.Equal(r.Video.ID, ids[i])
+	}
+	is.Equal(len(c.GetVideos(context.Background(), nil)), 0)
+}
+
+func TestBatchWorkers(t *testing.T) {
+	tests := map[string]struct {
+		workers int
+		want    int
+	}{
+		"default":       {workers: 0, want: 4},
+		"one":           {workers: 1, want: 1},
+		"three":         {workers: 3, want: 3},
+		"more than IDs": {workers: 50, want: 12},
+	}
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			is := is.New(t)
+			srv := pexelstest.NewServer()
+			defer srv.Close()
+			srv.SetLatency(20 * time.Millisecond)
+
+			var mu sync.Mutex
+			var inFlight, most int
+			c, err := srv.NewClient(
+				pexels.WithBatchWorkers(tc.workers),
+				pexels.WithHooks(pexels.Hooks{
+					Before: func(context.Context, pexels.RequestInfo) {
+						mu.Lock()
+						defer mu.Unlock()
+						inFlight++
+						most = max(most, inFlight)
+					},
+					After: func(context.Context, pexels.ResponseInfo) {
+						mu.Lock()
+						defer mu.Unlock()
+						inFlight--
+					},
+				}),
+			)
+			is.NoErr(err)
+
+			ids := make([]uint64, 12)
+			for i := range ids {
+				ids[i] = srv.Photos[i].ID
+			}
+			for _, r := range c.GetPhotos(context.Background(), ids) {
+				is.NoErr(r.Err)
+			}
+			is.Equal(srv.Requests(), len(ids))
+			is.Equal(most, tc.want)
+		})
+	}
+}
@@ -0,0 +1,279 @@
+package pexels
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/j-mnr/pexels-go/internal/atomicfile"
+)
+
+// Cache stores responses keyed on the full URL of their request. A Cache must
+// be safe for concurrent use. Since the key does not hold the API key, a Cache
+// should not be shared between clients using different API keys.
+type Cache interface {
+	Get(key string) (CachedResponse, bool)
+	Set(key string, resp CachedResponse)
+	Delete(key string)
+}
+
+// CachedResponse is a successful response held by a Cache. Once Expires has
+// passed it is revalidated with Pexels using its ETag or Last-Modified header,
+// when it has one.
+type CachedResponse struct {
+	StatusCode int         `json:"status_code"` //nolint:tagliatelle
+	Status     string      `json:"status"`
+	Header     http.Header `json:"headers"`
+	Body       []byte      `json:"body"`
+	Expires    time.Time   `json:"expires"`
+}
+
+// WithCache serves responses from cache while they are fresh. How long a
+// response stays fresh is decided by its Cache-Control header and otherwise by
+// DefaultCacheTTL, or the function given to WithCacheTTL.
+func WithCache(cache Cache) Option {
+	return func(cl *Client) { cl.cache = cache }
+}
+
+// WithCacheTTL sets how long a response to u stays fresh when Pexels does not
+// say so with a Cache-Control header. A TTL of zero or less only keeps the
+// response around for revalidation.
+func WithCacheTTL(ttl func(u *url.URL) time.Duration) Option {
+	return func(cl *Client) { cl.cacheTTL = ttl }
+}
+
+// DefaultCacheTTL keeps photos and videos fetched by ID for a day, the Curated
+// list for an hour, as it is updated hourly, and searches and popular videos
+// for fifteen minutes. Collections are not cached since they change whenever
+// you edit them.
+func DefaultCacheTTL(u *url.URL) time.Duration {
+	path := strings.TrimSuffix(u.Path, "/")
+	switch {
+	case strings.Contains(path, "/collections"):
+		return 0
+	case strings.Contains(path, "/photos/"),
+		strings.Contains(path, "/videos/videos/"):
+		return 24 * time.Hour
+	case strings.HasSuffix(path, "/curated"):
+		return time.Hour
+	case strings.HasSuffix(path, "/search"), strings.HasSuffix(path, "/popular"):
+		return 15 * time.Minute
+	}
+	return 0
+}
+
+// fetch returns the response to req from the Cache when it is fresh and
+// otherwise sends req, storing the response if it can be cached.
+func (c *Client) fetch(req *http.Request) (*rawResponse, error) {
+	if c.cache == nil {
+		return c.roundTrip(req)
+	}
+	key := req.URL.String()
+	cached, ok := c.cache.Get(key)
+	if ok && time.Now().Before(cached.Expires) {
+		return cached.raw(), nil
+	}
+	if ok {
+		if etag := cached.Header.Get("ETag"); etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+		if lm := cached.Header.Get("Last-Modified"); lm != "" {
+			req.Header.Set("If-Modified-Since", lm)
+		}
+	}
+
+	resp, err := c.roundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	if ok && resp.StatusCode == http.StatusNotModified {
+		// Keep the cached body, but take the newer headers so the rate limit
+		// values stay current.
+		cached.Header = cached.Header.Clone()
+		for k, v := range resp.Header {
+			cached.Header[k] = v
+		}
+		cached.Expires = time.Now().Add(c.freshness(req.URL, resp.Header))
+		c.cache.Set(key, cached)
+		return cached.raw(), nil
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp, nil
+	}
+
+	cc := parseCacheControl(resp.Header.Get("Cache-Control"))
+	if _, noStore := cc["no-store"]; noStore {
+		c.cache.Delete(key)
+		return resp, nil
+	}
+	ttl := c.freshness(req.URL, resp.Header)
+	if ttl > 0 || resp.Header.Get("ETag") != "" ||
+		resp.Header.Get("Last-Modified") != "" {
+		c.cache.Set(key, CachedResponse{
+			StatusCode: resp.StatusCode,
+			Status:     resp.Status,
+			Header:     resp.Header.Clone(),
+			Body:       resp.Body,
+			Expires:    time.Now().Add(ttl),
+		})
+	}
+	return resp, nil
+}
+
+// freshness is how long a response stays fresh according to its headers, or
+// the Client's cache TTL if the headers do not say.
+func (c *Client) freshness(u *url.URL, h http.Header) time.Duration {
+	cc := parseCacheControl(h.Get("Cache-Control"))
+	if _, noCache := cc["no-cache"]; noCache {
+		return 0
+	}
+	if secs, err := strconv.Atoi(cc["max-age"]); err == nil {
+		return time.Duration(secs) * time.Second
+	}
+	if c.cacheTTL != nil {
+		return c.cacheTTL(u)
+	}
+	return DefaultCacheTTL(u)
+}
+
+func parseCacheControl(v string) map[string]string {
+	cc := map[string]string{}
+	for _, directive := range strings.Split(v, ",") {
+		name, value, _ := strings.Cut(strings.TrimSpace(directive), "=")
+		if name != "" {
+			cc[strings.ToLower(name)] = strings.Trim(value, `"`)
+		}
+	}
+	return cc
+}
+
+func (cr CachedResponse) raw() *rawResponse {
+	return &rawResponse{
+		StatusCode: cr.StatusCode,
+		Status:     cr.Status,
+		Header:     cr.Header.Clone(),
+		Body:       cr.Body,
+		CacheHit:   true,
+	}
+}
+
+// LRUCache is an in-memory Cache holding up to a fixed number of responses,
+// evicting the least recently used one when full.
+type LRUCache struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List
+	entries  map[string]*list.Element
+}
+
+type lruEntry struct {
+	key  string
+	resp CachedResponse
+}
+
+// NewLRUCache returns an LRUCache holding up to capacity responses.
+func NewLRUCache(capacity int) *LRUCache {
+	return &LRUCache{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  map[string]*list.Element{},
+	}
+}
+
+// Get returns the response stored under key.
+func (c *LRUCache) Get(key string) (CachedResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.entries[key]
+	if !ok {
+		return CachedResponse{}, false
+	}
+	c.order.MoveToFront(el)
+	return el.Value.(*lruEntry).resp, true
+}
+
+// Set stores resp under key, evicting the least recently used response if
+// the LRUCache is full.
+func (c *LRUCache) Set(key string, resp CachedResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.entries[key]; ok {
+		el.Value.(*lruEntry).resp = resp
+		c.order.MoveToFront(el)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&lruEntry{key: key, resp: resp})
+	for c.capacity > 0 && c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lruEntry).key)
+	}
+}
+
+// Delete removes the response stored under key.
+func (c *LRUCache) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.entries[key]; ok {
+		c.order.Remove(el)
+		delete(c.entries, key)
+	}
+}
+
+// DiskCache is a Cache storing every response as a JSON file within a
+// directory, so it survives restarts. Failing to read or write a file is
+// treated as a cache miss.
+type DiskCache struct {
+	dir string
+}
+
+// NewDiskCache returns a DiskCache storing its files in dir, which is created
+// if it does not exist.
+func NewDiskCache(dir string) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf(wrapFmt, err)
+	}
+	return &DiskCache{dir: dir}, nil
+}
+
+func (c *DiskCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// Get returns the response stored under key.
+func (c *DiskCache) Get(key string) (CachedResponse, bool) {
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return CachedResponse{}, false
+	}
+	var resp CachedResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return CachedResponse{}, false
+	}
+	return resp, true
+}
+
+// Set stores resp under key.
+func (c *DiskCache) Set(key string, resp CachedResponse) {
+	data, err := json.Marshal(resp)
+	if err != nil {
+		return
+	}
+	_ = atomicfile.WriteFile(c.path(key), data)
+}
+
+// Delete removes the response stored under key.
+func (c *DiskCache) Delete(key string) {
+	os.Remove(c.path(key))
+}
@@ -0,0 +1,214 @@
+package pexels_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/j-mnr/pexels-go"
+	"github.com/matryer/is"
+)
+
+// etagServer serves photo 7 with the given Cache-Control and an ETag, and
+// answers 304 Not Modified when the ETag is sent back.
+type etagServer struct {
+	*httptest.Server
+
+	mu           sync.Mutex
+	requests     int
+	revalidated  int
+	cacheControl string
+	etag         string
+}
+
+func newETagServer(cacheControl, etag string) *etagServer {
+	s := &etagServer{cacheControl: cacheControl, etag: etag}
+	s.Server = httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			s.requests++
+			h := w.Header()
+			h.Set("X-Ratelimit-Remaining", "100")
+			if s.cacheControl != "" {
+				h.Set("Cache-Control", s.cacheControl)
+			}
+			if s.etag != "" {
+				h.Set("ETag", s.etag)
+				if r.Header.Get("If-None-Match") == s.etag {
+					s.revalidated++
+					h.Set("X-Ratelimit-Remaining", "99")
+					w.WriteHeader(http.StatusNotModified)
+					return
+				}
+			}
+			h.Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"id":7,"photographer":"cached"}`))
+		}))
+	return s
+}
+
+func (s *etagServer) newClient(opts ...pexels.Option) (*pexels.Client, error) {
+	opts = append([]pexels.Option{pexels.WithHTTPClient(s.Client())}, opts...)
+	c, err := pexels.New("key", opts...)
+	if err != nil {
+		return nil, err
+	}
+	c.RootPhotoURL = s.URL + "/v1"
+	c.RootVideoURL = s.URL + "/videos"
+	return c, nil
+}
+
+func TestCache(t *testing.T) {
+	zeroTTL := pexels.WithCacheTTL(func(*url.URL) time.Duration { return 0 })
+	tests := map[string]struct {
+		cacheControl    string
+		etag            string
+		opts            []pexels.Option
+		wantRequests    int
+		wantRevalidated int
+		wantRemaining   string
+	}{
+		"fresh by max-age": {
+			cacheControl: "max-age=60", opts: []pexels.Option{zeroTTL},
+			wantRequests: 1, wantRemaining: "100",
+		},
+		"fresh by DefaultCacheTTL": {
+			wantRequests: 1, wantRemaining: "100",
+		},
+		"max-age over DefaultCacheTTL": {
+			cacheControl: "max-age=0", wantRequests: 2, wantRemaining: "100",
+		},
+		"WithCacheTTL": {
+			opts: []pexels.Option{zeroTTL}, wantRequests: 2, wantRemaining: "100",
+		},
+		"no-store": {
+			cacheControl: "no-store", etag: `"v1"`,
+			wantRequests: 2, wantRemaining: "100",
+		},
+		"revalidated with ETag": {
+			cacheControl: "no-cache", etag: `"v1"`,
+			wantRequests: 2, wantRevalidated: 1, wantRemaining: "99",
+		},
+	}
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			is := is.New(t)
+			srv := newETagServer(tc.cacheControl, tc.etag)
+			defer srv.Close()
+			opts := append([]pexels.Option{
+				pexels.WithCache(pexels.NewLRUCache(10)),
+			}, tc.opts...)
+			c, err := srv.newClient(opts...)
+			is.NoErr(err)
+
+			for i := 0; i < 2; i++ {
+				pr, err := c.GetPhoto(context.Background(), 7)
+				is.NoErr(err)
+				is.Equal(pr.Photo.Photographer, "cached")
+				if i == 1 {
+					is.Equal(pr.Common.Header.Get("X-Ratelimit-Remaining"),
+						tc.wantRemaining)
+				}
+			}
+			is.Equal(srv.requests, tc.wantRequests)
+			is.Equal(srv.revalidated, tc.wantRevalidated)
+		})
+	}
+}
+
+func TestCacheKeepsHeaderOfCaller(t *testing.T) {
+	is := is.New(t)
+	srv := newETagServer("max-age=60", "")
+	defer srv.Close()
+	c, err := srv.newClient(pexels.WithCache(pexels.NewLRUCache(10)))
+	is.NoErr(err)
+
+	pr, err := c.GetPhoto(context.Background(), 7)
+	is.NoErr(err)
+	pr.Common.Header.Set("X-Ratelimit-Remaining", "edited")
+
+	pr, err = c.GetPhoto(context.Background(), 7)
+	is.NoErr(err)
+	is.Equal(pr.Common.Header.Get("X-Ratelimit-Remaining"), "100")
+	is.Equal(srv.requests, 1)
+}
+
+func TestDefaultCacheTTL(t *testing.T) {
+	tests := map[string]time.Duration{
+		"https://api.pexels.com/v1/photos/7":         24 * time.Hour,
+		"https://api.pexels.com/videos/videos/7":     24 * time.Hour,
+		"https://api.pexels.com/v1/curated?page=2":   time.Hour,
+		"https://api.pexels.com/v1/search?query=sea": 15 * time.Minute,
+		"https://api.pexels.com/videos/popular/":     15 * time.Minute,
+		"https://api.pexels.com/v1/collections/abc":  0,
+		"https://api.pexels.com/v1/collections":      0,
+	}
+	for rawURL, want := range tests {
+		u, err := url.Parse(rawURL)
+		is.New(t).NoErr(err)
+		if got := pexels.DefaultCacheTTL(u); got != want {
+			t.Errorf("DefaultCacheTTL(%s) = %s, want %s", rawURL, got, want)
+		}
+	}
+}
+
+func TestLRUCacheEvicts(t *testing.T) {
+	is := is.New(t)
+	c := pexels.NewLRUCache(2)
+	c.Set("a", pexels.CachedResponse{Status: "a"})
+	c.Set("b", pexels.CachedResponse{Status: "b"})
+	_, ok := c.Get("a")
+	is.True(ok)
+	c.Set("c", pexels.CachedResponse{Status: "c"})
+
+	_, ok = c.Get("b")
+	is.True(!ok) // least recently used
+	for _, key := range []string{"a", "c"} {
+		resp, ok := c.Get(key)
+		is.True(ok)
+		is.Equal(resp.Status, key)
+	}
+	c.Delete("a")
+	_, ok = c.Get("a")
+	is.True(!ok)
+}
+
+func TestDiskCache(t *testing.T) {
+	is := is.New(t)
+	dir := t.TempDir()
+	c, err := pexels.NewDiskCache(dir)
+	is.NoErr(err)
+	key := "https://api.pexels.com/v1/photos/7"
+	_, ok := c.Get(key)
+	is.True(!ok)
+
+	want := pexels.CachedResponse{
+		StatusCode: http.StatusOK,
+		Status:     "200 OK",
+		Header:     http.Header{"Etag": {`"v1"`}},
+		Body:       []byte(`{"id":7}`),
+		Expires:    time.Now().Add(time.Hour).Round(0).UTC(),
+	}
+	c.Set(key, want)
+
+	// A new DiskCache over the same directory sees what was stored.
+	c, err = pexels.NewDiskCache(dir)
+	is.NoErr(err)
+	got, ok := c.Get(key)
+	is.True(ok)
+	is.Equal(got.StatusCode, want.StatusCode)
+	is.Equal(got.Status, want.Status)
+	is.Equal(got.Header, want.Header)
+	is.Equal(got.Body, want.Body)
+	is.True(got.Expires.Equal(want.Expires))
+
+	c.Delete(key)
+	_, ok = c.Get(key)
+	is.True(!ok)
+}
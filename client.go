@@ -1,17 +1,21 @@
 package pexels
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
-	"reflect"
+	"net/url"
 	"strings"
 	"time"
 )
 
 var (
-	ErrMissingAPIKey   = errors.New("an API Key is required")
+	ErrMissingAPIKey = errors.New("an API Key is required")
+	// Deprecated: media of an unsupported type is decoded into an UnknownMedia
+	// and this error is no longer returned.
 	ErrUnsupportedType = errors.New("the type specified is cannot be unmarshalled into a Video or Photo")
 )
 
@@ -32,11 +36,22 @@ type HTTPClient interface {
 }
 
 // Client is the Pexels API Client that allows you to interact with the Pexels
-// endpoints for photos, videos, and collections.
+// endpoints for photos, videos, and collections. Every method takes a
+// context.Context which is attached to the outgoing HTTP request, so
+// cancellation and deadlines are honored by the underlying HTTPClient.
 type Client struct {
 	apiKey string
 
-	client HTTPClient
+	client     HTTPClient
+	downloader HTTPClient // Without a timeout, media takes a while to fetch.
+	retry      RetryPolicy
+	limiter    *limiter
+	cache      Cache
+	cacheTTL   func(u *url.URL) time.Duration
+	flights    *flightGroup
+	hooks      []Hooks
+
+	batchWorkers int
 
 	RootPhotoURL string
 	RootVideoURL string
@@ -49,8 +64,9 @@ func New(apiKey string, opts ...Option) (*Client, error) {
 		return nil, ErrMissingAPIKey
 	}
 	c := &Client{
-		apiKey:    apiKey,
-		client:    &http.Client{Timeout: time.Second},
+		apiKey:       apiKey,
+		client:       &http.Client{Timeout: time.Second},
+		downloader:   &http.Client{},
 		RootPhotoURL: RootPhotoURL,
 		RootVideoURL: RootVideoURL,
 	}
@@ -65,17 +81,39 @@ func New(apiKey string, opts ...Option) (*Client, error) {
 type Option func(*Client)
 
 func WithHTTPClient(c HTTPClient) Option {
-	return func(cl *Client) { cl.client = c }
+	return func(cl *Client) {
+		cl.client = c
+		cl.downloader = c
+	}
 }
 
 func get[T any](
-	c Client, path string, reqData any, respData T,
+	ctx context.Context, c Client, path string, reqData queryEncoder, respData T,
 ) (response[T], error) {
-	req, err := c.newRequest(path, reqData)
+	req, err := c.newRequest(ctx, path, reqData)
 	if err != nil {
 		return response[T]{}, err
 	}
+	return do(c, req, respData)
+}
+
+// getURL is like get but for a full URL given back by Pexels, such as
+// Pagination.NextPage. The URL must point at RootPhotoURL or RootVideoURL so
+// the API key is never sent anywhere else.
+func getURL[T any](
+	ctx context.Context, c Client, rawURL string, respData T,
+) (response[T], error) {
+	if !c.ownsURL(rawURL) {
+		return response[T]{}, ErrForeignURL
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return response[T]{}, fmt.Errorf(wrapFmt, err)
+	}
+	return do(c, req, respData)
+}
 
+func do[T any](c Client, req *http.Request, respData T) (response[T], error) {
 	res := response[T]{Data: respData}
 	if err := doRequest(c, req, &res); err != nil {
 		return response[T]{}, err
@@ -85,74 +123,91 @@ func get[T any](
 
 func doRequest[T any](c Client, req *http.Request, resp *response[T]) error {
 	setRequestHeaders(req, c.apiKey)
-	httpResp, err := c.client.Do(req)
+	raw, err := c.coalesce(req)
 	if err != nil {
 		return fmt.Errorf(wrapFmt, err)
 	}
-	defer httpResp.Body.Close()
 
-	resp.Common.Header = httpResp.Header
-	resp.Common.StatusCode = httpResp.StatusCode
-	resp.Common.Status = httpResp.Status
-	if err = json.NewDecoder(httpResp.Body).Decode(resp.Data); err != nil {
+	if raw.StatusCode < 200 || raw.StatusCode > 299 {
+		return newAPIError(raw)
+	}
+	resp.Common.Header = raw.Header
+	resp.Common.StatusCode = raw.StatusCode
+	resp.Common.Status = raw.Status
+	resp.Common.CacheHit = raw.CacheHit
+	if err = json.Unmarshal(raw.Body, resp.Data); err != nil {
 		return fmt.Errorf(wrapFmt, err)
 	}
 	return nil
 }
 
-func (c *Client) newRequest(path string, data any) (*http.Request, error) {
+// rawResponse is a response with its body read in full, so it can be cached
+// and decoded more than once.
+type rawResponse struct {
+	StatusCode int
+	Status     string
+	Header     http.Header
+	Body       []byte
+	CacheHit   bool
+}
+
+// roundTrip sends req over the network and reads the whole response.
+func (c *Client) roundTrip(req *http.Request) (*rawResponse, error) {
+	httpResp, err := c.send(req)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+	body, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, err
+	}
+	return &rawResponse{
+		StatusCode: httpResp.StatusCode,
+		Status:     httpResp.Status,
+		Header:     httpResp.Header,
+		Body:       body,
+	}, nil
+}
+
+func (c *Client) newRequest(
+	ctx context.Context, path string, data queryEncoder,
+) (*http.Request, error) {
 	url := c.RootPhotoURL + path
-	if strings.HasPrefix(path, "/videos") {
-		url = c.RootVideoURL + path
+	if strings.HasPrefix(path, "/videos/") {
+		url = c.RootVideoURL + strings.TrimPrefix(path, "/videos")
 	}
-	req, err := http.NewRequest(http.MethodGet, url, nil) //nolint:noctx
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, fmt.Errorf(wrapFmt, err)
 	}
-	req.URL.RawQuery = buildQueryString(req, data)
+	if data != nil {
+		req.URL.RawQuery = data.Encode()
+	}
 	return req, nil
 }
 
-func setRequestHeaders(req *http.Request, apiKey string) {
-	req.Header.Set("Authorization", apiKey)
-	req.Header.Set("Accept", "application/json")
-}
-
-func buildQueryString(req *http.Request, v any) string {
-	if isZero(v) {
-		return ""
+// ownsURL reports whether rawURL lives under RootPhotoURL or RootVideoURL.
+func (c *Client) ownsURL(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
 	}
-
-	query := req.URL.Query()
-	vType := reflect.TypeOf(v).Elem()
-	vValue := reflect.ValueOf(v).Elem()
-	for i := 0; i < vType.NumField(); i++ {
-		var defaultValue string
-
-		field := vType.Field(i)
-		tag := field.Tag.Get("query")
-		if strings.Contains(tag, ",") {
-			tagSlice := strings.Split(tag, ",")
-			tag = tagSlice[0]
-			defaultValue = tagSlice[1]
+	for _, root := range []string{c.RootPhotoURL, c.RootVideoURL} {
+		r, err := url.Parse(root)
+		if err != nil {
+			continue
 		}
-
-		// Add any scalar values as query params
-		fieldValue := fmt.Sprintf("%v", vValue.Field(i))
-		// If no value was set by the user, use the default value specified in
-		// the struct tag
-		if fieldValue == "" || fieldValue == "0" {
-			if defaultValue == "" {
-				continue
-			}
-			fieldValue = defaultValue
+		rootPath := strings.TrimSuffix(r.Path, "/")
+		if u.Scheme == r.Scheme && u.Host == r.Host &&
+			(u.Path == rootPath || strings.HasPrefix(u.Path, rootPath+"/")) {
+			return true
 		}
-		query.Add(tag, fieldValue)
 	}
-	return query.Encode()
+	return false
 }
 
-func isZero[T comparable](v T) bool {
-	var zero T
-	return v == zero
+func setRequestHeaders(req *http.Request, apiKey string) {
+	req.Header.Set("Authorization", apiKey)
+	req.Header.Set("Accept", "application/json")
 }
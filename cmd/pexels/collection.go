@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"strconv"
+
+	"github.com/j-mnr/pexels-go"
+)
+
+var collectionHeaders = []string{
+	"ID", "TITLE", "MEDIA", "PHOTOS", "VIDEOS", "PRIVATE",
+}
+
+func collectionRow(c pexels.Collection) []string {
+	return []string{
+		c.ID, c.Title, itoa(c.MediaCount), itoa(c.PhotosCount),
+		itoa(c.VideosCount), strconv.FormatBool(c.Private),
+	}
+}
+
+var mediaHeaders = []string{"TYPE", "ID", "CREATOR", "URL"}
+
+func mediaRow(m pexels.Media) []string {
+	a := m.Attribution()
+	var id string
+	switch v := m.(type) {
+	case *pexels.Photo:
+		id = itoa(v.ID)
+	case *pexels.Video:
+		id = itoa(v.ID)
+	}
+	return []string{a.Kind, id, a.Name, a.SourceURL}
+}
+
+func collectionsList(ctx context.Context, c *pexels.Client, args []string) error {
+	fs := flag.NewFlagSet("collections list", flag.ContinueOnError)
+	pf := addPageFlags(fs)
+	if _, err := parseFlags(fs, args, 0); err != nil {
+		return err
+	}
+	var params pexels.CollectionParams
+	var err error
+	if params.Page, params.PerPage, err = pf.params(); err != nil {
+		return err
+	}
+	return list(ctx, c.CollectionsPager(&params), pf,
+		collectionHeaders, collectionRow)
+}
+
+func collectionGet(ctx context.Context, c *pexels.Client, args []string) error {
+	fs := flag.NewFlagSet("collection get", flag.ContinueOnError)
+	var params pexels.CollectionMediaParams
+	fs.StringVar(&params.Type, "type", "", "only list photos or videos")
+	pf := addPageFlags(fs)
+	pos, err := parseFlags(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	params.ID = pos[0]
+	if params.Page, params.PerPage, err = pf.params(); err != nil {
+		return err
+	}
+	return list(ctx, c.CollectionPager(&params), pf, mediaHeaders, mediaRow)
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/j-mnr/pexels-go"
+)
+
+var variants = map[string]pexels.PhotoVariant{
+	"original":  pexels.VariantOriginal,
+	"large2x":   pexels.VariantLarge2x,
+	"large":     pexels.VariantLarge,
+	"medium":    pexels.VariantMedium,
+	"small":     pexels.VariantSmall,
+	"portrait":  pexels.VariantPortrait,
+	"landscape": pexels.VariantLandscape,
+	"tiny":      pexels.VariantTiny,
+}
+
+// downloadFlags are the flags shared by both download commands.
+type downloadFlags struct {
+	output string
+	resume bool
+	quiet  bool
+}
+
+func addDownloadFlags(fs *flag.FlagSet) *downloadFlags {
+	df := &downloadFlags{}
+	fs.StringVar(&df.output, "o", "", "file to save to, named after the URL by default")
+	fs.BoolVar(&df.resume, "resume", false, "continue a partial download of the file")
+	fs.BoolVar(&df.quiet, "quiet", false, "do not report progress")
+	return df
+}
+
+func downloadPhoto(ctx context.Context, c *pexels.Client, args []string) error {
+	fs := flag.NewFlagSet("download photo", flag.ContinueOnError)
+	variant := fs.String("variant", "original",
+		"size to download: original, large2x, large, medium, small, portrait, landscape or tiny")
+	df := addDownloadFlags(fs)
+	pos, err := parseFlags(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	v, ok := variants[strings.ToLower(*variant)]
+	if !ok {
+		return flagError(fs, fmt.Errorf("unknown variant %q", *variant))
+	}
+	id, err := parseID(pos[0])
+	if err != nil {
+		return err
+	}
+	resp, err := c.GetPhoto(ctx, id)
+	if err != nil {
+		return err
+	}
+	name := fmt.Sprintf("%d-%s%s", id, v, pexels.FileExt(resp.Photo.Src.URL(v)))
+	return save(df, name, func(f *os.File, opts ...pexels.DownloadOption) error {
+		_, err := c.DownloadPhoto(ctx, resp.Photo, v, f, opts...)
+		return err
+	})
+}
+
+func downloadVideo(ctx context.Context, c *pexels.Client, args []string) error {
+	fs := flag.NewFlagSet("download video", flag.ContinueOnError)
+	var fc pexels.FileConstraints
+	var maxWidth, maxHeight uint
+	quality := fs.String("quality", "uhd,hd,sd",
+		"accepted qualities, most preferred first")
+	fs.UintVar(&maxWidth, "max-width", 0, "widest file accepted in pixels")
+	fs.UintVar(&maxHeight, "max-height", 0, "tallest file accepted in pixels")
+	fs.StringVar(&fc.FileType, "file-type", "video/mp4", "accepted MIME type")
+	df := addDownloadFlags(fs)
+	pos, err := parseFlags(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	if err := checkUint16(uint16Flag{"max-width", maxWidth},
+		uint16Flag{"max-height", maxHeight}); err != nil {
+		return err
+	}
+	fc.MaxWidth, fc.MaxHeight = uint16(maxWidth), uint16(maxHeight)
+	fc.Qualities = splitList(*quality)
+	id, err := parseID(pos[0])
+	if err != nil {
+		return err
+	}
+	resp, err := c.GetVideo(ctx, id)
+	if err != nil {
+		return err
+	}
+	file, ok := resp.Video.BestFile(fc)
+	if !ok {
+		return fmt.Errorf("video %d has no file matching the given flags", id)
+	}
+	name := fmt.Sprintf("%d-%s-%dx%d%s",
+		id, file.Quality, file.Width, file.Height, pexels.FileExt(file.Link))
+	return save(df, name, func(f *os.File, opts ...pexels.DownloadOption) error {
+		_, err := c.DownloadVideo(ctx, file, f, opts...)
+		return err
+	})
+}
+
+// save creates the file to download to and reports the progress on stderr.
+func save(
+	df *downloadFlags, name string,
+	download func(f *os.File, opts ...pexels.DownloadOption) error,
+) error {
+	if df.output != "" {
+		name = df.output
+	}
+	flags := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	if df.resume {
+		flags = os.O_WRONLY | os.O_CREATE | os.O_APPEND
+	}
+	f, err := os.OpenFile(name, flags, 0o644)
+	if err != nil {
+		return err
+	}
+	var opts []pexels.DownloadOption
+	if df.resume {
+		info, err := f.Stat()
+		if err != nil {
+			f.Close()
+			return err
+		}
+		opts = append(opts, pexels.ResumeFrom(info.Size()))
+	}
+	if !df.quiet {
+		opts = append(opts, pexels.OnProgress(progress(name)))
+	}
+	err = download(f, opts...)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if !df.quiet {
+		fmt.Fprintln(os.Stderr)
+	}
+	return err
+}
+
+// progress returns an OnProgress callback printing at most a few updates a
+// second.
+func progress(name string) func(written, total int64) {
+	var last time.Time
+	return func(written, total int64) {
+		if time.Since(last) < 100*time.Millisecond && written != total {
+			return
+		}
+		last = time.Now()
+		if total < 0 {
+			fmt.Fprintf(os.Stderr, "\r%s: %d bytes", name, written)
+			return
+		}
+		fmt.Fprintf(os.Stderr, "\r%s: %d/%d bytes (%d%%)",
+			name, written, total, written*100/max(total, 1))
+	}
+}
+
+// splitList splits a comma separated flag value.
+func splitList(s string) []string {
+	var list []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			list = append(list, v)
+		}
+	}
+	return list
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/j-mnr/pexels-go"
+	"github.com/j-mnr/pexels-go/export"
+)
+
+// exportFlags are the flags shared by all export commands.
+type exportFlags struct {
+	cfg     export.Config
+	format  string
+	perPage uint
+	quiet   bool
+}
+
+func addExportFlags(fs *flag.FlagSet) *exportFlags {
+	ef := &exportFlags{}
+	fs.StringVar(&ef.cfg.Dir, "dir", "pexels-export", "directory to write the files to")
+	fs.StringVar(&ef.format, "format", "jsonl,csv", "formats to write: jsonl, csv or both")
+	fs.IntVar(&ef.cfg.Limit, "limit", 0, "stop after this many media")
+	fs.BoolVar(&ef.cfg.Restart, "restart", false, "start over instead of resuming")
+	fs.UintVar(&ef.perPage, "per-page", 80, "results per request, at most 80")
+	fs.BoolVar(&ef.quiet, "quiet", false, "do not report progress")
+	return ef
+}
+
+// run does the export once cfg has a source.
+func (ef *exportFlags) run(
+	ctx context.Context, c *pexels.Client, fs *flag.FlagSet,
+) error {
+	for _, f := range strings.Split(ef.format, ",") {
+		switch strings.ToLower(strings.TrimSpace(f)) {
+		case "jsonl":
+			ef.cfg.JSONL = true
+		case "csv":
+			ef.cfg.CSV = true
+		default:
+			return flagError(fs, fmt.Errorf("unknown format %q", f))
+		}
+	}
+	if !ef.quiet {
+		ef.cfg.Progress = func(s export.Stats) {
+			fmt.Fprintf(os.Stderr, "\rpages: %d, media: %d, duplicates: %d",
+				s.Pages, s.Media, s.Duplicates)
+		}
+	}
+	stats, err := export.Run(ctx, c, ef.cfg)
+	if !ef.quiet {
+		fmt.Fprintln(os.Stderr)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "exported %d media and %d video files to %s\n",
+		stats.Media, stats.VideoFiles, ef.cfg.Dir)
+	return nil
+}
+
+func (ef *exportFlags) perPageParam() (uint8, error) {
+	if ef.perPage > 80 {
+		return 0, fmt.Errorf("--per-page must be at most 80")
+	}
+	return uint8(ef.perPage), nil
+}
+
+func exportPhotos(ctx context.Context, c *pexels.Client, args []string) error {
+	fs := flag.NewFlagSet("export photos", flag.ContinueOnError)
+	var params pexels.PhotoSearchParams
+	generalFlags(fs, &params.General)
+	fs.Func("color", "a named color such as red, or a hex code", func(s string) error {
+		color, err := pexels.ParseColor(s)
+		params.Color = color
+		return err
+	})
+	ef := addExportFlags(fs)
+	pos, err := parseFlags(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	params.Query = pos[0]
+	if params.PerPage, err = ef.perPageParam(); err != nil {
+		return err
+	}
+	ef.cfg.PhotoSearch = &params
+	return ef.run(ctx, c, fs)
+}
+
+func exportVideos(ctx context.Context, c *pexels.Client, args []string) error {
+	fs := flag.NewFlagSet("export videos", flag.ContinueOnError)
+	var params pexels.VideoSearchParams
+	generalFlags(fs, &params.General)
+	ef := addExportFlags(fs)
+	pos, err := parseFlags(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	params.Query = pos[0]
+	if params.PerPage, err = ef.perPageParam(); err != nil {
+		return err
+	}
+	ef.cfg.VideoSearch = &params
+	return ef.run(ctx, c, fs)
+}
+
+func exportCollection(ctx context.Context, c *pexels.Client, args []string) error {
+	fs := flag.NewFlagSet("export collection", flag.ContinueOnError)
+	var params pexels.CollectionMediaParams
+	fs.StringVar(&params.Type, "type", "", "only export photos or videos")
+	ef := addExportFlags(fs)
+	pos, err := parseFlags(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	params.ID = pos[0]
+	if params.PerPage, err = ef.perPageParam(); err != nil {
+		return err
+	}
+	ef.cfg.Collection = &params
+	return ef.run(ctx, c, fs)
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+
+	"github.com/j-mnr/pexels-go"
+)
+
+// pageFlags are the flags of every command listing a paginated endpoint.
+type pageFlags struct {
+	page    uint
+	perPage uint
+	all     bool
+	limit   int
+	output  *string
+}
+
+func addPageFlags(fs *flag.FlagSet) *pageFlags {
+	pf := &pageFlags{}
+	fs.UintVar(&pf.page, "page", 1, "page to start at")
+	fs.UintVar(&pf.perPage, "per-page", 15, "results per page, at most 80")
+	fs.BoolVar(&pf.all, "all", false, "keep fetching pages until there are none left")
+	fs.IntVar(&pf.limit, "limit", 0, "stop after this many results")
+	pf.output = addOutputFlag(fs)
+	return pf
+}
+
+// params returns the page and per page flags as the types the params use.
+func (pf *pageFlags) params() (page uint16, perPage uint8, err error) {
+	if pf.page > math.MaxUint16 {
+		return 0, 0, fmt.Errorf("--page must be at most %d", math.MaxUint16)
+	}
+	if pf.perPage == 0 || pf.perPage > 80 {
+		return 0, 0, fmt.Errorf("--per-page must be between 1 and 80")
+	}
+	return uint16(pf.page), uint8(pf.perPage), nil
+}
+
+// list writes every item of p to stdout. Without --all only a single page is
+// written.
+func list[T any](
+	ctx context.Context, p *pexels.Pager[T], pf *pageFlags,
+	headers []string, row func(T) []string,
+) error {
+	out, err := newOutput(os.Stdout, *pf.output)
+	if err != nil {
+		return err
+	}
+	limit := pf.limit
+	if !pf.all && (limit <= 0 || limit > int(pf.perPage)) {
+		limit = int(pf.perPage)
+	}
+	p.Limit(limit)
+	for p.Next(ctx) {
+		item := p.Item()
+		if err := out.write(item, headers, row(item)); err != nil {
+			return err
+		}
+	}
+	if err := p.Err(); err != nil {
+		return err
+	}
+	return out.flush()
+}
+
+// writeOne writes a single item to stdout.
+func writeOne(format string, item any, headers, columns []string) error {
+	out, err := newOutput(os.Stdout, format)
+	if err != nil {
+		return err
+	}
+	if err := out.write(item, headers, columns); err != nil {
+		return err
+	}
+	return out.flush()
+}
+
+// generalFlags adds the flags for the params shared by photo and video
+// searches.
+func generalFlags(fs *flag.FlagSet, g *pexels.General) {
+	fs.Func("locale", "locale of the search, e.g. en-US", func(s string) error {
+		l, err := pexels.ParseLocale(s)
+		g.Locale = l
+		return err
+	})
+	fs.Func("orientation", "landscape, portrait or square", func(s string) error {
+		o, err := pexels.ParseOrientation(s)
+		g.Orientation = o
+		return err
+	})
+	fs.Func("size", "minimum size: small, medium or large", func(s string) error {
+		sz, err := pexels.ParseSize(s)
+		g.Size = sz
+		return err
+	})
+}
+
+// uint16Flag is a uint flag that is passed on as a uint16.
+type uint16Flag struct {
+	name  string
+	value uint
+}
+
+// checkUint16 returns an error for the first flag that does not fit a uint16.
+func checkUint16(flags ...uint16Flag) error {
+	for _, f := range flags {
+		if f.value > math.MaxUint16 {
+			return fmt.Errorf("--%s must be at most %d", f.name, math.MaxUint16)
+		}
+	}
+	return nil
+}
+
+func parseID(s string) (uint64, error) {
+	id, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid ID %q", s)
+	}
+	return id, nil
+}
+
+func itoa[T ~uint8 | ~uint16 | ~uint32 | ~uint64](v T) string {
+	return strconv.FormatUint(uint64(v), 10)
+}
@@ -0,0 +1,171 @@
+// Command pexels is a command-line client for the Pexels API.
+//
+// It reads the API key from the PEXELS_API_KEY environment variable and
+// mirrors the API with subcommands:
+//
+//	pexels photo get <id>
+//	pexels photo search <query>
+//	pexels photo curated
+//	pexels video get <id>
+//	pexels video search <query>
+//	pexels video popular
+//	pexels collections list
+//	pexels collections sync
+//	pexels collection get <id>
+//	pexels download photo <id>
+//	pexels download video <id>
+//	pexels export photos <query>
+//	pexels export videos <query>
+//	pexels export collection <id>
+//
+// Run any subcommand with -h to see its flags.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/j-mnr/pexels-go"
+)
+
+const usage = `Usage: pexels <command> <subcommand> [flags] [args]
+
+Commands:
+  photo get <id>           Get a photo by its ID
+  photo search <query>     Search for photos
+  photo curated            List the curated photos
+  video get <id>           Get a video by its ID
+  video search <query>     Search for videos
+  video popular            List the popular videos
+  collections list         List your collections
+  collections sync         Mirror your collections to a directory
+  collection get <id>      List the media within one of your collections
+  download photo <id>      Save a photo to disk
+  download video <id>      Save a video to disk
+  export photos <query>    Export a photo search to JSONL and CSV
+  export videos <query>    Export a video search to JSONL and CSV
+  export collection <id>   Export a collection to JSONL and CSV
+
+The API key is read from the PEXELS_API_KEY environment variable.
+`
+
+// errUsage is returned when the command line could not be understood; the
+// usage has already been printed by then.
+var errUsage = errors.New("usage")
+
+type command func(ctx context.Context, c *pexels.Client, args []string) error
+
+var commands = map[string]map[string]command{
+	"photo": {
+		"get":     photoGet,
+		"search":  photoSearch,
+		"curated": photoCurated,
+	},
+	"video": {
+		"get":     videoGet,
+		"search":  videoSearch,
+		"popular": videoPopular,
+	},
+	"collections": {
+		"list": collectionsList,
+		"sync": collectionsSync,
+	},
+	"collection": {
+		"get": collectionGet,
+	},
+	"download": {
+		"photo": downloadPhoto,
+		"video": downloadVideo,
+	},
+	"export": {
+		"photos":     exportPhotos,
+		"videos":     exportVideos,
+		"collection": exportCollection,
+	},
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	err := run(ctx, os.Args[1:])
+	stop()
+	switch {
+	case errors.Is(err, errUsage):
+		os.Exit(2)
+	case err != nil:
+		fmt.Fprintln(os.Stderr, "pexels:", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string) error {
+	if len(args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		return errUsage
+	}
+	cmd, ok := commands[args[0]][args[1]]
+	if !ok {
+		fmt.Fprint(os.Stderr, usage)
+		return errUsage
+	}
+	c, err := pexels.New(os.Getenv("PEXELS_API_KEY"),
+		pexels.WithHTTPClient(newHTTPClient()),
+		pexels.WithRetry(pexels.DefaultRetryPolicy()),
+	)
+	if errors.Is(err, pexels.ErrMissingAPIKey) {
+		return errors.New("the PEXELS_API_KEY environment variable is not set")
+	}
+	if err != nil {
+		return err
+	}
+	return cmd(ctx, c, args[2:])
+}
+
+// parseFlags parses args with fs, allowing flags to come after the positional
+// arguments, and returns the positional arguments of which there must be
+// exactly want.
+func parseFlags(fs *flag.FlagSet, args []string, want int) ([]string, error) {
+	fs.SetOutput(io.Discard)
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, flagError(fs, err)
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			break
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+	if len(positional) != want {
+		return nil, flagError(fs, fmt.Errorf(
+			"expected %d argument(s), got %d", want, len(positional)))
+	}
+	return positional, nil
+}
+
+func flagError(fs *flag.FlagSet, err error) error {
+	fs.SetOutput(os.Stderr)
+	if !errors.Is(err, flag.ErrHelp) {
+		fmt.Fprintln(os.Stderr, err)
+	}
+	fmt.Fprintf(os.Stderr, "Usage of %s:\n", fs.Name())
+	fs.PrintDefaults()
+	return errUsage
+}
+
+// newHTTPClient returns the client used for all requests. Unlike the default
+// of the library it has no overall timeout, so downloads of large videos are
+// not cut short; only waiting on a response is bounded.
+func newHTTPClient() *http.Client {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.ResponseHeaderTimeout = 30 * time.Second
+	return &http.Client{Transport: t}
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// output writes items in the format chosen with --output: a table, a single
+// JSON array or one JSON object per line.
+type output struct {
+	format string
+	w      io.Writer
+	tw     *tabwriter.Writer
+	items  []any
+	header bool
+}
+
+func addOutputFlag(fs *flag.FlagSet) *string {
+	return fs.String("output", "table", "output format: table, json or ndjson")
+}
+
+func newOutput(w io.Writer, format string) (*output, error) {
+	switch format {
+	case "table", "json", "ndjson":
+	default:
+		return nil, fmt.Errorf("unknown output format %q", format)
+	}
+	return &output{
+		format: format,
+		w:      w,
+		tw:     tabwriter.NewWriter(w, 0, 4, 2, ' ', 0),
+	}, nil
+}
+
+// write outputs item, using columns as its row within a table of headers.
+func (o *output) write(item any, headers, columns []string) error {
+	switch o.format {
+	case "json":
+		o.items = append(o.items, item)
+		return nil
+	case "ndjson":
+		return json.NewEncoder(o.w).Encode(item)
+	}
+	if !o.header {
+		o.header = true
+		if _, err := fmt.Fprintln(o.tw, strings.Join(headers, "\t")); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintln(o.tw, strings.Join(columns, "\t"))
+	return err
+}
+
+// flush writes out anything held back until all items are known.
+func (o *output) flush() error {
+	if o.format != "json" {
+		return o.tw.Flush()
+	}
+	if o.items == nil {
+		o.items = []any{}
+	}
+	enc := json.NewEncoder(o.w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(o.items)
+}
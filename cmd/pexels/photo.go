@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"flag"
+
+	"github.com/j-mnr/pexels-go"
+)
+
+var photoHeaders = []string{"ID", "WIDTH", "HEIGHT", "PHOTOGRAPHER", "URL"}
+
+func photoRow(p pexels.Photo) []string {
+	return []string{
+		itoa(p.ID), itoa(p.Width), itoa(p.Height), p.Photographer, p.URL,
+	}
+}
+
+func photoGet(ctx context.Context, c *pexels.Client, args []string) error {
+	fs := flag.NewFlagSet("photo get", flag.ContinueOnError)
+	output := addOutputFlag(fs)
+	pos, err := parseFlags(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	id, err := parseID(pos[0])
+	if err != nil {
+		return err
+	}
+	resp, err := c.GetPhoto(ctx, id)
+	if err != nil {
+		return err
+	}
+	return writeOne(*output, resp.Photo, photoHeaders, photoRow(resp.Photo))
+}
+
+func photoSearch(ctx context.Context, c *pexels.Client, args []string) error {
+	fs := flag.NewFlagSet("photo search", flag.ContinueOnError)
+	var params pexels.PhotoSearchParams
+	generalFlags(fs, &params.General)
+	fs.Func("color", "a named color such as red, or a hex code", func(s string) error {
+		color, err := pexels.ParseColor(s)
+		params.Color = color
+		return err
+	})
+	pf := addPageFlags(fs)
+	pos, err := parseFlags(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	params.Query = pos[0]
+	if params.Page, params.PerPage, err = pf.params(); err != nil {
+		return err
+	}
+	return list(ctx, c.SearchPhotosPager(&params), pf, photoHeaders, photoRow)
+}
+
+func photoCurated(ctx context.Context, c *pexels.Client, args []string) error {
+	fs := flag.NewFlagSet("photo curated", flag.ContinueOnError)
+	pf := addPageFlags(fs)
+	if _, err := parseFlags(fs, args, 0); err != nil {
+		return err
+	}
+	var params pexels.CuratedPhotosParams
+	var err error
+	if params.Page, params.PerPage, err = pf.params(); err != nil {
+		return err
+	}
+	return list(ctx, c.CuratedPhotosPager(&params), pf, photoHeaders, photoRow)
+}
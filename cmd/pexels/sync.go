@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/j-mnr/pexels-go"
+	"github.com/j-mnr/pexels-go/mirror"
+)
+
+func collectionsSync(ctx context.Context, c *pexels.Client, args []string) error {
+	fs := flag.NewFlagSet("collections sync", flag.ContinueOnError)
+	var cfg mirror.Config
+	var maxWidth, maxHeight uint
+	fs.StringVar(&cfg.Dir, "dir", "pexels-mirror", "directory holding the mirror")
+	fs.BoolVar(&cfg.Assets, "assets", false, "download the photos and videos too")
+	fs.BoolVar(&cfg.Prune, "prune", false, "remove assets no longer in any collection")
+	variant := fs.String("variant", "original", "size of the photos to download")
+	quality := fs.String("quality", "uhd,hd,sd",
+		"accepted video qualities, most preferred first")
+	fs.UintVar(&maxWidth, "max-width", 0, "widest video file accepted in pixels")
+	fs.UintVar(&maxHeight, "max-height", 0, "tallest video file accepted in pixels")
+	asJSON := fs.Bool("json", false, "print the change report as JSON")
+	if _, err := parseFlags(fs, args, 0); err != nil {
+		return err
+	}
+	v, ok := variants[strings.ToLower(*variant)]
+	if !ok {
+		return flagError(fs, fmt.Errorf("unknown variant %q", *variant))
+	}
+	if err := checkUint16(uint16Flag{"max-width", maxWidth},
+		uint16Flag{"max-height", maxHeight}); err != nil {
+		return err
+	}
+	cfg.PhotoVariant = v
+	cfg.VideoFile = pexels.FileConstraints{
+		MaxWidth:  uint16(maxWidth),
+		MaxHeight: uint16(maxHeight),
+		Qualities: splitList(*quality),
+		FileType:  "video/mp4",
+	}
+
+	report, err := mirror.Sync(ctx, c, cfg)
+	if err != nil {
+		return err
+	}
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	}
+	if report.Empty() {
+		fmt.Println("no changes")
+	}
+	fmt.Print(report)
+	if cfg.Assets {
+		fmt.Fprintf(os.Stderr, "downloaded %d and pruned %d assets\n",
+			report.Downloaded, report.Pruned)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"flag"
+
+	"github.com/j-mnr/pexels-go"
+)
+
+var videoHeaders = []string{"ID", "WIDTH", "HEIGHT", "DURATION", "USER", "URL"}
+
+func videoRow(v pexels.Video) []string {
+	return []string{
+		itoa(v.ID), itoa(v.Width), itoa(v.Height), itoa(v.Duration) + "s",
+		v.User.Name, v.URL,
+	}
+}
+
+func videoGet(ctx context.Context, c *pexels.Client, args []string) error {
+	fs := flag.NewFlagSet("video get", flag.ContinueOnError)
+	output := addOutputFlag(fs)
+	pos, err := parseFlags(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	id, err := parseID(pos[0])
+	if err != nil {
+		return err
+	}
+	resp, err := c.GetVideo(ctx, id)
+	if err != nil {
+		return err
+	}
+	return writeOne(*output, resp.Video, videoHeaders, videoRow(resp.Video))
+}
+
+func videoSearch(ctx context.Context, c *pexels.Client, args []string) error {
+	fs := flag.NewFlagSet("video search", flag.ContinueOnError)
+	var params pexels.VideoSearchParams
+	generalFlags(fs, &params.General)
+	pf := addPageFlags(fs)
+	pos, err := parseFlags(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	params.Query = pos[0]
+	if params.Page, params.PerPage, err = pf.params(); err != nil {
+		return err
+	}
+	return list(ctx, c.SearchVideosPager(&params), pf, videoHeaders, videoRow)
+}
+
+func videoPopular(ctx context.Context, c *pexels.Client, args []string) error {
+	fs := flag.NewFlagSet("video popular", flag.ContinueOnError)
+	var minWidth, minHeight, minDuration, maxDuration uint
+	fs.UintVar(&minWidth, "min-width", 0, "minimum width in pixels")
+	fs.UintVar(&minHeight, "min-height", 0, "minimum height in pixels")
+	fs.UintVar(&minDuration, "min-duration", 0, "minimum duration in seconds")
+	fs.UintVar(&maxDuration, "max-duration", 0, "maximum duration in seconds")
+	pf := addPageFlags(fs)
+	if _, err := parseFlags(fs, args, 0); err != nil {
+		return err
+	}
+	if err := checkUint16(
+		uint16Flag{"min-width", minWidth}, uint16Flag{"min-height", minHeight},
+		uint16Flag{"min-duration", minDuration},
+		uint16Flag{"max-duration", maxDuration},
+	); err != nil {
+		return err
+	}
+	params := pexels.PopularVideoParams{
+		MinWidth:    uint16(minWidth),
+		MinHeight:   uint16(minHeight),
+		MinDuration: uint16(minDuration),
+		MaxDuration: uint16(maxDuration),
+	}
+	var err error
+	if params.Page, params.PerPage, err = pf.params(); err != nil {
+		return err
+	}
+	return list(ctx, c.PopularVideosPager(&params), pf, videoHeaders, videoRow)
+}
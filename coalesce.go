@@ -0,0 +1,107 @@
+package pexels
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+)
+
+// CoalesceStats counts the requests that went through a Client made with
+// WithCoalescing.
+type CoalesceStats struct {
+	// Requests is the number of requests made through the Client.
+	Requests uint64
+	// Coalesced is the number of those requests that shared the response of
+	// an identical request already in flight instead of making their own.
+	Coalesced uint64
+}
+
+// WithCoalescing makes concurrent identical requests, same URL and API key,
+// share a single HTTP call and all receive its result.
+func WithCoalescing() Option {
+	return func(cl *Client) {
+		cl.flights = &flightGroup{calls: map[string]*flight{}}
+	}
+}
+
+// CoalesceStats returns how many requests were coalesced so far. It is always
+// zero unless the Client was made with WithCoalescing.
+func (c *Client) CoalesceStats() CoalesceStats {
+	if c.flights == nil {
+		return CoalesceStats{}
+	}
+	c.flights.mu.Lock()
+	defer c.flights.mu.Unlock()
+	return c.flights.stats
+}
+
+// coalesce fetches req, sharing the response with any identical request made
+// while it is in flight.
+func (c *Client) coalesce(req *http.Request) (*rawResponse, error) {
+	if c.flights == nil {
+		return c.fetch(req)
+	}
+	key := req.Method + " " + req.URL.String() + " " + c.apiKey
+	return c.flights.do(req.Context(), key, func() (*rawResponse, error) {
+		return c.fetch(req)
+	})
+}
+
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flight
+	stats CoalesceStats
+}
+
+type flight struct {
+	done chan struct{}
+	resp *rawResponse
+	err  error
+}
+
+// do calls fn unless a call for key is already in flight, in which case its
+// result is waited for instead. If that call was stopped by its own context
+// while ctx is still live, fn is called after all.
+func (g *flightGroup) do(
+	ctx context.Context, key string, fn func() (*rawResponse, error),
+) (*rawResponse, error) {
+	g.mu.Lock()
+	g.stats.Requests++
+	if f, ok := g.calls[key]; ok {
+		g.stats.Coalesced++
+		g.mu.Unlock()
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-f.done:
+		}
+		if ctx.Err() == nil && (errors.Is(f.err, context.Canceled) ||
+			errors.Is(f.err, context.DeadlineExceeded)) {
+			return fn()
+		}
+		return f.resp.clone(), f.err
+	}
+	f := &flight{done: make(chan struct{})}
+	g.calls[key] = f
+	g.mu.Unlock()
+
+	f.resp, f.err = fn()
+
+	g.mu.Lock()
+	delete(g.calls, key)
+	g.mu.Unlock()
+	close(f.done)
+	return f.resp.clone(), f.err
+}
+
+// clone copies r so every caller sharing it gets its own Header. The Body is
+// only ever read and is not copied.
+func (r *rawResponse) clone() *rawResponse {
+	if r == nil {
+		return nil
+	}
+	cp := *r
+	cp.Header = r.Header.Clone()
+	return &cp
+}
@@ -0,0 +1,119 @@
+package pexels_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/j-mnr/pexels-go"
+	"github.com/j-mnr/pexels-go/pexelstest"
+	"github.com/matryer/is"
+)
+
+// waitFor polls cond until it holds, failing the test after a few seconds.
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	for deadline := time.Now().Add(5 * time.Second); !cond(); {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met in time")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestCoalescing(t *testing.T) {
+	const n = 8
+	is := is.New(t)
+	srv := pexelstest.NewServer()
+	defer srv.Close()
+	c, err := srv.NewClient(pexels.WithCoalescing())
+	is.NoErr(err)
+	srv.SetLatency(200 * time.Millisecond)
+	id := srv.Photos[0].ID
+
+	var wg sync.WaitGroup
+	responses := make([]pexels.PhotoResponse, n)
+	errs := make([]error, n)
+	get := func(i int) {
+		defer wg.Done()
+		responses[i], errs[i] = c.GetPhoto(context.Background(), id)
+	}
+	wg.Add(n)
+	go get(0)
+	waitFor(t, func() bool { return srv.Requests() == 1 })
+	for i := 1; i < n; i++ {
+		go get(i)
+	}
+	wg.Wait()
+
+	for i := range responses {
+		is.NoErr(errs[i])
+		is.Equal(responses[i].Photo.ID, id)
+	}
+	is.Equal(srv.Requests(), 1)
+	is.Equal(c.CoalesceStats(), pexels.CoalesceStats{Requests: n, Coalesced: n - 1})
+
+	// Every caller gets a Header of its own.
+	responses[0].Common.Header.Set("X-Ratelimit-Limit", "0")
+	is.True(responses[1].Common.Header.Get("X-Ratelimit-Limit") != "0")
+}
+
+func TestCoalescingCanceled(t *testing.T) {
+	tests := map[string]struct {
+		cancelLeader bool
+		wantRequests int
+	}{
+		// The waiter makes the request itself once the leader gives up.
+		"leader": {cancelLeader: true, wantRequests: 2},
+		// The leader carries on without the waiter.
+		"waiter": {cancelLeader: false, wantRequests: 1},
+	}
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			is := is.New(t)
+			srv := pexelstest.NewServer()
+			defer srv.Close()
+			c, err := srv.NewClient(pexels.WithCoalescing())
+			is.NoErr(err)
+			srv.SetLatency(200 * time.Millisecond)
+			id := srv.Photos[0].ID
+
+			leaderCtx, cancelLeader := context.WithCancel(context.Background())
+			defer cancelLeader()
+			waiterCtx, cancelWaiter := context.WithCancel(context.Background())
+			defer cancelWaiter()
+			var leaderErr, waiterErr error
+			var wg sync.WaitGroup
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				_, leaderErr = c.GetPhoto(leaderCtx, id)
+			}()
+			waitFor(t, func() bool { return srv.Requests() == 1 })
+			go func() {
+				defer wg.Done()
+				_, waiterErr = c.GetPhoto(waiterCtx, id)
+			}()
+			waitFor(t, func() bool { return c.CoalesceStats().Coalesced == 1 })
+			if tc.cancelLeader {
+				cancelLeader()
+			} else {
+				cancelWaiter()
+			}
+			wg.Wait()
+
+			if tc.cancelLeader {
+				is.True(errors.Is(leaderErr, context.Canceled))
+				is.NoErr(waiterErr)
+			} else {
+				is.NoErr(leaderErr)
+				is.True(errors.Is(waiterErr, context.Canceled))
+			}
+			is.Equal(srv.Requests(), tc.wantRequests)
+			is.Equal(c.CoalesceStats(), pexels.CoalesceStats{Requests: 2, Coalesced: 1})
+		})
+	}
+}
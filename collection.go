@@ -1,9 +1,11 @@
 package pexels
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/url"
 )
 
 var ErrMissingCollectionID = errors.New("a collection ID must be specified")
@@ -11,6 +13,7 @@ var ErrMissingCollectionID = errors.New("a collection ID must be specified")
 // Media is either Photo or Video.
 type Media interface {
 	MediaType() Type
+	Attribution() Attribution
 	isMedia()
 }
 
@@ -49,9 +52,9 @@ type MediaPayload struct {
 
 func (p *MediaPayload) UnmarshalJSON(raw []byte) error {
 	var data struct {
-		ID         string            `json:"id"`
-		Media      []json.RawMessage `json:"media"`
-		Pagination `json:"pagination"`
+		ID    string            `json:"id"`
+		Media []json.RawMessage `json:"media"`
+		Pagination
 	}
 	if err := json.Unmarshal(raw, &data); err != nil {
 		return fmt.Errorf(wrapFmt, err)
@@ -70,25 +73,63 @@ func (p *MediaPayload) UnmarshalJSON(raw []byte) error {
 	return nil
 }
 
+// decodeMediaFrom decodes a single element of a collection into a Photo or
+// Video depending on its type. Types this package does not know about yet are
+// kept as an UnknownMedia instead of failing the whole page.
 func decodeMediaFrom(data []byte) (Media, error) {
 	var typeData struct {
-		Type Type `json:"type"`
+		Type string `json:"type"`
 	}
 	if err := json.Unmarshal(data, &typeData); err != nil {
 		return nil, fmt.Errorf(wrapFmt, err)
 	}
 	var m Media
-	switch typeData.Type {
+	switch typ(typeData.Type) {
 	case TypeVideo:
 		m = &Video{}
 	case TypePhoto:
 		m = &Photo{}
 	default:
-		return nil, ErrUnsupportedType
+		raw := make(json.RawMessage, len(data))
+		copy(raw, data)
+		return &UnknownMedia{Type: typeData.Type, Raw: raw}, nil
+	}
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, fmt.Errorf(wrapFmt, err)
 	}
 	return m, nil
 }
 
+// UnknownMedia is a Media of a type this package does not support yet. Raw
+// holds the JSON it was sent as so it can still be decoded by the caller.
+type UnknownMedia struct {
+	Type string
+	Raw  json.RawMessage
+}
+
+func (UnknownMedia) isMedia() {}
+
+// MediaType returns the type Pexels gave the UnknownMedia.
+func (u UnknownMedia) MediaType() Type { return typ(u.Type) }
+
+// ID returns the "id" found in the raw JSON, or an empty string if there is
+// none.
+func (u UnknownMedia) ID() string {
+	var data struct {
+		ID json.Number `json:"id"`
+	}
+	_ = json.Unmarshal(u.Raw, &data)
+	return data.ID.String()
+}
+
+// MarshalJSON returns the UnknownMedia exactly as it was received.
+func (u UnknownMedia) MarshalJSON() ([]byte, error) {
+	if u.Raw == nil {
+		return []byte("null"), nil
+	}
+	return u.Raw, nil
+}
+
 // CollectionPayload is all of the user's Collections.
 type CollectionPayload struct {
 	ID          string       `json:"id"`
@@ -98,10 +139,12 @@ type CollectionPayload struct {
 
 // MediaResponse is all media given back from a single collection, even though
 // videos and photos are in the response, they may be empty slices if your
-// collection doesn't have either.
+// collection doesn't have either. Media holds every element, including the
+// ones of an UnknownMedia type, in the order they appear in the collection.
 type MediaResponse struct {
 	Common ResponseCommon
 	ID     string
+	Media  []Media
 	Videos []Video
 	Photos []Photo
 	Pagination
@@ -124,8 +167,29 @@ type CollectionsResponse struct {
 // CollectionParams allows you to pick which page to start at in your
 // collections and how many per page you want.
 type CollectionParams struct {
-	Page    uint16 `query:"page,1"`
-	PerPage uint8  `query:"per_page,15"` // Max: 80
+	Page    uint16 // Default: 1
+	PerPage uint8  // Default: 15, Max: 80
+}
+
+// Encode returns the URL encoded query string of the params.
+func (p *CollectionParams) Encode() string {
+	if p == nil {
+		return ""
+	}
+	q := url.Values{}
+	setUint(q, "page", p.Page, 1)
+	setUint(q, "per_page", p.PerPage, 15)
+	return q.Encode()
+}
+
+// Validate reports every field of the params Pexels would reject.
+func (p *CollectionParams) Validate() error {
+	if p == nil {
+		return nil
+	}
+	var fe fieldErrors
+	fe.checkPerPage(p.PerPage)
+	return fe.err()
 }
 
 // CollectionMediaParams is the way to get back a single collection. If you're
@@ -135,25 +199,54 @@ type CollectionMediaParams struct {
 	ID string
 
 	// Supported types are: videos, photos.
-	Type    string `query:"type"`
-	Page    uint16 `query:"page,1"`
-	PerPage uint8  `query:"per_page,15"` // Max: 80
+	Type    string
+	Page    uint16 // Default: 1
+	PerPage uint8  // Default: 15, Max: 80
+}
+
+// Encode returns the URL encoded query string of the params. The ID is part
+// of the path and is not included.
+func (p *CollectionMediaParams) Encode() string {
+	if p == nil {
+		return ""
+	}
+	q := url.Values{}
+	setString(q, "type", p.Type)
+	setUint(q, "page", p.Page, 1)
+	setUint(q, "per_page", p.PerPage, 15)
+	return q.Encode()
+}
+
+// Validate reports every field of the params Pexels would reject, including a
+// missing ID.
+func (p *CollectionMediaParams) Validate() error {
+	if p == nil {
+		return &FieldError{Field: "ID", Value: "", Err: ErrMissingCollectionID}
+	}
+	var fe fieldErrors
+	if p.ID == "" {
+		fe.add("ID", p.ID, ErrMissingCollectionID)
+	}
+	if p.Type != "" && p.Type != "videos" && p.Type != "photos" {
+		fe.addf("Type", p.Type, "must be videos or photos")
+	}
+	fe.checkPerPage(p.PerPage)
+	return fe.err()
 }
 
 // GetCollection returns all the media based on parameters provided, within a
 // single collection.
 func (c *Client) GetCollection(
-	params *CollectionMediaParams,
+	ctx context.Context, params *CollectionMediaParams,
 ) (MediaResponse, error) {
-	if params == nil || params.ID == "" {
-		return MediaResponse{}, ErrMissingCollectionID
-	}
-	id := params.ID
-	params.ID = ""
-	resp, err := get(*c, fmt.Sprint("/collections/", id), params, &MediaPayload{})
+	resp, err := c.getCollectionMedia(ctx, params)
 	if err != nil {
 		return MediaResponse{}, err
 	}
+	return newMediaResponse(resp), nil
+}
+
+func newMediaResponse(resp response[*MediaPayload]) MediaResponse {
 	cr := MediaResponse{}
 	for _, m := range resp.Data.Media {
 		switch v := m.(type) {
@@ -164,14 +257,31 @@ func (c *Client) GetCollection(
 		}
 	}
 	cr.ID = resp.Data.ID
+	cr.Media = resp.Data.Media
 	cr.Pagination = resp.Data.Pagination
 	resp.copyCommon(&cr.Common)
-	return cr, nil
+	return cr
+}
+
+func (c *Client) getCollectionMedia(
+	ctx context.Context, params *CollectionMediaParams,
+) (response[*MediaPayload], error) {
+	if err := params.Validate(); err != nil {
+		return response[*MediaPayload]{}, err
+	}
+	return get(ctx, *c, fmt.Sprint("/collections/", params.ID), params,
+		&MediaPayload{})
 }
 
-// GetCollections returns all of your collections.
-func (c *Client) GetCollections() (CollectionsResponse, error) {
-	resp, err := get(*c, "/collections", "", &CollectionPayload{})
+// GetCollections returns your collections. If nil is passed it will default to
+// the first page and return 15 collections.
+func (c *Client) GetCollections(
+	ctx context.Context, params *CollectionParams,
+) (CollectionsResponse, error) {
+	if err := params.Validate(); err != nil {
+		return CollectionsResponse{}, err
+	}
+	resp, err := get(ctx, *c, "/collections", params, &CollectionPayload{})
 	if err != nil {
 		return CollectionsResponse{}, err
 	}
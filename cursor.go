@@ -0,0 +1,140 @@
+package pexels
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/j-mnr/pexels-go/internal/atomicfile"
+)
+
+var ErrCursorMismatch = errors.New("the cursor belongs to a pager of another kind")
+
+// Cursor is the position of a Pager. It is meant to be stored, e.g. as JSON
+// through a CheckpointStore, so a long running job can resume a Pager with
+// ResumePager after it was stopped.
+//
+//nolint:tagliatelle
+type Cursor struct {
+	// Endpoint is the path of the paginated endpoint, e.g. "/search".
+	Endpoint string `json:"endpoint"`
+	// Query holds the encoded params of the Pager, without the page.
+	Query string `json:"query"`
+	// Page is the page the next item comes from.
+	Page uint16 `json:"page"`
+	// SeenIDs are the IDs of the items of Page that were already yielded.
+	// They are skipped when resuming.
+	SeenIDs []string `json:"seen_ids,omitempty"`
+	// Done is set once every page was fetched and yielded.
+	Done    bool      `json:"done"`
+	Started time.Time `json:"started"`
+	Updated time.Time `json:"updated"`
+}
+
+// newCursor returns the Cursor at the start of endpoint for the params q.
+func newCursor(endpoint string, q queryEncoder) Cursor {
+	query, _ := url.ParseQuery(q.Encode())
+	page, _ := strconv.ParseUint(query.Get("page"), 10, 16)
+	query.Del("page")
+	return Cursor{Endpoint: endpoint, Query: query.Encode(), Page: uint16(page)}
+}
+
+// ResumePager returns a Pager continuing where the Pager that cur was taken
+// from left off. T must be the type of item of that Pager, otherwise
+// ErrCursorMismatch is returned. The params are not validated again.
+//
+//	p, err := pexels.ResumePager[pexels.Photo](client, cur)
+func ResumePager[T any](c *Client, cur Cursor) (*Pager[T], error) {
+	var p any
+	switch {
+	case cur.Endpoint == searchPhotosEndpoint,
+		cur.Endpoint == curatedPhotosEndpoint:
+		p = newPager(c, cur, nil, photoItems, photoKey)
+	case cur.Endpoint == searchVideosEndpoint,
+		cur.Endpoint == popularVideosEndpoint:
+		p = newPager(c, cur, nil, videoItems, videoKey)
+	case cur.Endpoint == "/collections":
+		p = newPager(c, cur, nil, collectionItems, collectionKey)
+	case strings.HasPrefix(cur.Endpoint, "/collections/"):
+		p = newPager(c, cur, nil, mediaItems, mediaKey)
+	}
+	pager, ok := p.(*Pager[T])
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrCursorMismatch, cur.Endpoint)
+	}
+	return pager, nil
+}
+
+// CheckpointStore keeps Cursors under a name, such as the name of the job
+// using them. A CheckpointStore must be safe for concurrent use.
+type CheckpointStore interface {
+	// Load returns the Cursor saved under name, reporting false if there is
+	// none.
+	Load(name string) (Cursor, bool, error)
+	Save(name string, cur Cursor) error
+	Delete(name string) error
+}
+
+// FileCheckpointStore is a CheckpointStore keeping every Cursor as a JSON
+// file within a directory.
+type FileCheckpointStore struct {
+	dir string
+}
+
+// NewFileCheckpointStore returns a FileCheckpointStore storing its files in
+// dir, which is created if it does not exist.
+func NewFileCheckpointStore(dir string) (*FileCheckpointStore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf(wrapFmt, err)
+	}
+	return &FileCheckpointStore{dir: dir}, nil
+}
+
+func (s *FileCheckpointStore) path(name string) string {
+	return filepath.Join(s.dir, url.PathEscape(name)+".json")
+}
+
+// Load returns the Cursor saved under name.
+func (s *FileCheckpointStore) Load(name string) (Cursor, bool, error) {
+	data, err := os.ReadFile(s.path(name))
+	if errors.Is(err, fs.ErrNotExist) {
+		return Cursor{}, false, nil
+	}
+	if err != nil {
+		return Cursor{}, false, fmt.Errorf(wrapFmt, err)
+	}
+	var cur Cursor
+	if err := json.Unmarshal(data, &cur); err != nil {
+		return Cursor{}, false, fmt.Errorf(wrapFmt, err)
+	}
+	return cur, true, nil
+}
+
+// Save stores cur under name, replacing the Cursor saved before.
+func (s *FileCheckpointStore) Save(name string, cur Cursor) error {
+	data, err := json.Marshal(cur)
+	if err != nil {
+		return fmt.Errorf(wrapFmt, err)
+	}
+	if err := atomicfile.WriteFile(s.path(name), data); err != nil {
+		return fmt.Errorf(wrapFmt, err)
+	}
+	return nil
+}
+
+// Delete removes the Cursor saved under name. Deleting a name that does not
+// exist is not an error.
+func (s *FileCheckpointStore) Delete(name string) error {
+	err := os.Remove(s.path(name))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf(wrapFmt, err)
+	}
+	return nil
+}
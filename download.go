@@ -0,0 +1,180 @@
+package pexels
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrMissingDownloadURL = errors.New("there is no URL to download from")
+	ErrIncompleteDownload = errors.New("the download ended before all of the content was received")
+	ErrUnexpectedRange    = errors.New("the server sent another range than was asked for")
+)
+
+// DownloadOption are the options you can pass to DownloadPhoto and
+// DownloadVideo.
+type DownloadOption func(*downloadOptions)
+
+type downloadOptions struct {
+	offset   int64
+	progress func(written, total int64)
+}
+
+// ResumeFrom continues a download that stopped after offset bytes were
+// written. Only the remaining bytes are requested using an HTTP Range header;
+// if the server does not support ranges the first offset bytes are skipped.
+func ResumeFrom(offset int64) DownloadOption {
+	return func(o *downloadOptions) { o.offset = offset }
+}
+
+// OnProgress calls fn every time a chunk is written. written includes the
+// offset given to ResumeFrom and total is -1 when the size is unknown.
+func OnProgress(fn func(written, total int64)) DownloadOption {
+	return func(o *downloadOptions) { o.progress = fn }
+}
+
+// DownloadPhoto streams the variant of p to w and returns the number of bytes
+// written. The request goes straight to the Pexels CDN and never carries the
+// API key, nor does it count against the rate limiter. Unless WithHTTPClient
+// was given, downloads are only bound by the deadline of ctx.
+func (c *Client) DownloadPhoto(
+	ctx context.Context, p Photo, v PhotoVariant, w io.Writer,
+	opts ...DownloadOption,
+) (int64, error) {
+	return c.download(ctx, p.Src.URL(v), w, opts)
+}
+
+// DownloadVideo streams the VideoFile f to w and returns the number of bytes
+// written. Like DownloadPhoto the API key is never sent along.
+func (c *Client) DownloadVideo(
+	ctx context.Context, f VideoFile, w io.Writer, opts ...DownloadOption,
+) (int64, error) {
+	return c.download(ctx, f.Link, w, opts)
+}
+
+func (c *Client) download(
+	ctx context.Context, rawURL string, w io.Writer, opts []DownloadOption,
+) (int64, error) {
+	var o downloadOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if rawURL == "" {
+		return 0, ErrMissingDownloadURL
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return 0, fmt.Errorf(wrapFmt, err)
+	}
+	if o.offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", o.offset))
+	}
+	resp, err := c.downloader.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf(wrapFmt, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusRequestedRangeNotSatisfiable && o.offset > 0 {
+		// Resuming a download that already completed asks for the range just
+		// past the end, which the server answers with "bytes */<size>".
+		if _, size := parseContentRange(resp.Header.Get("Content-Range")); size == o.offset {
+			if o.progress != nil {
+				o.progress(size, size)
+			}
+			return 0, nil
+		}
+	}
+
+	total := int64(-1)
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+		start, size := parseContentRange(resp.Header.Get("Content-Range"))
+		if start != o.offset {
+			return 0, fmt.Errorf("pexels: %w: asked for byte %d, got byte %d",
+				ErrUnexpectedRange, o.offset, start)
+		}
+		total = size
+	case http.StatusOK:
+		if resp.ContentLength >= 0 {
+			total = resp.ContentLength
+		}
+		// The server ignored the Range, skip what was already written.
+		if _, err := io.CopyN(io.Discard, resp.Body, o.offset); err != nil {
+			return 0, fmt.Errorf(wrapFmt, err)
+		}
+	default:
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		return 0, newAPIError(&rawResponse{
+			StatusCode: resp.StatusCode,
+			Status:     resp.Status,
+			Header:     resp.Header,
+			Body:       body,
+		})
+	}
+
+	pw := &progressWriter{w: w, written: o.offset, total: total, fn: o.progress}
+	n, err := io.Copy(pw, resp.Body)
+	if err != nil {
+		return n, fmt.Errorf(wrapFmt, err)
+	}
+	if total >= 0 && pw.written != total {
+		return n, fmt.Errorf("pexels: %w: got %d of %d bytes",
+			ErrIncompleteDownload, pw.written, total)
+	}
+	return n, nil
+}
+
+// FileExt returns the extension of the file rawURL points to, such as
+// ".jpeg" for a PhotoSource URL or ".mp4" for a VideoFile link, or an empty
+// string if it has none.
+func FileExt(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return path.Ext(u.Path)
+}
+
+// parseContentRange returns the first byte and the complete size from a
+// Content-Range header such as "bytes 100-199/200" or "bytes */200". Either
+// is -1 if it is unknown.
+func parseContentRange(h string) (start, size int64) {
+	start, size = -1, -1
+	rng, total, ok := strings.Cut(strings.TrimPrefix(h, "bytes "), "/")
+	if !ok {
+		return start, size
+	}
+	if n, err := strconv.ParseInt(total, 10, 64); err == nil {
+		size = n
+	}
+	if first, _, ok := strings.Cut(rng, "-"); ok {
+		if n, err := strconv.ParseInt(first, 10, 64); err == nil {
+			start = n
+		}
+	}
+	return start, size
+}
+
+type progressWriter struct {
+	w       io.Writer
+	written int64
+	total   int64
+	fn      func(written, total int64)
+}
+
+func (pw *progressWriter) Write(p []byte) (int, error) {
+	n, err := pw.w.Write(p)
+	pw.written += int64(n)
+	if pw.fn != nil {
+		pw.fn(pw.written, pw.total)
+	}
+	return n, err
+}
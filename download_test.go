@@ -0,0 +1,120 @@
+package pexels_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/j-mnr/pexels-go"
+	"github.com/matryer/is"
+)
+
+func TestDownloadVideoResume(t *testing.T) {
+	content := strings.Repeat("0123456789", 100)
+	srv := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			if auth := r.Header.Get("Authorization"); auth != "" {
+				t.Errorf("the API key %q was sent to the CDN", auth)
+			}
+			http.ServeContent(w, r, "video.mp4", time.Time{},
+				strings.NewReader(content))
+		}))
+	defer srv.Close()
+	// The same HTTP client is used for the API and for downloads.
+	c, err := pexels.New("key", pexels.WithHTTPClient(srv.Client()))
+	is.New(t).NoErr(err)
+	file := pexels.VideoFile{Link: srv.URL + "/video.mp4"}
+
+	tests := map[string]struct {
+		offset  int64
+		want    string
+		written int64
+	}{
+		"from the start": {0, content, int64(len(content))},
+		"partial":        {400, content[400:], int64(len(content) - 400)},
+		"complete":       {int64(len(content)), "", 0},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			is := is.New(t)
+			var buf bytes.Buffer
+			var progress, total int64
+			n, err := c.DownloadVideo(context.Background(), file, &buf,
+				pexels.ResumeFrom(tt.offset),
+				pexels.OnProgress(func(written, size int64) {
+					progress, total = written, size
+				}))
+			is.NoErr(err)
+			is.Equal(n, tt.written)
+			is.Equal(buf.String(), tt.want)
+			is.Equal(progress, int64(len(content)))
+			is.Equal(total, int64(len(content)))
+		})
+	}
+}
+
+func TestDownloadVideoUnexpectedRange(t *testing.T) {
+	is := is.New(t)
+	srv := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Range", "bytes 0-9/10")
+			w.WriteHeader(http.StatusPartialContent)
+			w.Write([]byte("0123456789"))
+		}))
+	defer srv.Close()
+	c, err := pexels.New("key")
+	is.NoErr(err)
+
+	var buf bytes.Buffer
+	_, err = c.DownloadVideo(context.Background(),
+		pexels.VideoFile{Link: srv.URL}, &buf, pexels.ResumeFrom(5))
+	is.True(errors.Is(err, pexels.ErrUnexpectedRange))
+	is.Equal(buf.Len(), 0)
+}
+
+func TestDownloadPhotoWithoutAPIKey(t *testing.T) {
+	is := is.New(t)
+	var auth []string
+	srv := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			auth = append(auth, r.Header.Get("Authorization"))
+			w.Write([]byte("jpeg"))
+		}))
+	defer srv.Close()
+	c, err := pexels.New("key", pexels.WithHTTPClient(srv.Client()))
+	is.NoErr(err)
+
+	var buf bytes.Buffer
+	photo := pexels.Photo{Src: pexels.PhotoSource{Original: srv.URL + "/1.jpeg"}}
+	n, err := c.DownloadPhoto(context.Background(), photo,
+		pexels.VariantOriginal, &buf)
+	is.NoErr(err)
+	is.Equal(n, int64(4))
+	is.Equal(auth, []string{""})
+}
+
+func TestDownloadVideoIncomplete(t *testing.T) {
+	is := is.New(t)
+	srv := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			// Claims a 20 byte file but only ever sends up to byte 9.
+			w.Header().Set("Content-Range", "bytes 5-9/20")
+			w.WriteHeader(http.StatusPartialContent)
+			w.Write([]byte("56789"))
+		}))
+	defer srv.Close()
+	c, err := pexels.New("key")
+	is.NoErr(err)
+
+	var buf bytes.Buffer
+	n, err := c.DownloadVideo(context.Background(),
+		pexels.VideoFile{Link: srv.URL}, &buf, pexels.ResumeFrom(5))
+	is.True(errors.Is(err, pexels.ErrIncompleteDownload))
+	is.Equal(n, int64(5))
+	is.Equal(buf.String(), "56789")
+}
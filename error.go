@@ -0,0 +1,85 @@
+package pexels
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+var (
+	ErrNotFound     = errors.New("the requested resource was not found")
+	ErrUnauthorized = errors.New("the API key was rejected")
+	ErrRateLimited  = errors.New("the rate limit has been exceeded")
+)
+
+// maxErrorBody is the most bytes read from the body of a failed response.
+const maxErrorBody = 64 << 10
+
+// APIError is returned whenever Pexels responds with a non-2xx status code.
+// It can be matched against ErrNotFound, ErrUnauthorized and ErrRateLimited
+// with errors.Is, or inspected with errors.As for the full details. The status
+// code and headers are found in Common.
+type APIError struct {
+	Common ResponseCommon
+	// Message is the error message given back by Pexels, if any could be
+	// found in the body.
+	Message string
+	// Body is the raw body of the response.
+	Body []byte
+}
+
+func (e *APIError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("pexels: %s", e.Common.Status)
+	}
+	return fmt.Sprintf("pexels: %s: %s", e.Common.Status, e.Message)
+}
+
+// Is reports whether the status code of the APIError corresponds to one of
+// the sentinel errors ErrNotFound, ErrUnauthorized or ErrRateLimited.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.Common.StatusCode == http.StatusNotFound
+	case ErrUnauthorized:
+		return e.Common.StatusCode == http.StatusUnauthorized ||
+			e.Common.StatusCode == http.StatusForbidden
+	case ErrRateLimited:
+		return e.Common.StatusCode == http.StatusTooManyRequests
+	}
+	return false
+}
+
+func newAPIError(resp *rawResponse) *APIError {
+	body := resp.Body
+	if len(body) > maxErrorBody {
+		body = body[:maxErrorBody]
+	}
+	return &APIError{
+		Common: ResponseCommon{
+			StatusCode: resp.StatusCode,
+			Status:     resp.Status,
+			Header:     resp.Header,
+		},
+		Message: errorMessage(body),
+		Body:    body,
+	}
+}
+
+// errorMessage pulls the message out of an error body. Pexels answers with
+// either a small JSON object or plain text depending on the failure.
+func errorMessage(body []byte) string {
+	var data struct {
+		Error   string `json:"error"`
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(body, &data); err == nil {
+		if data.Error != "" {
+			return data.Error
+		}
+		return data.Message
+	}
+	return strings.TrimSpace(string(body))
+}
@@ -0,0 +1,59 @@
+package export
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/j-mnr/pexels-go"
+	"github.com/j-mnr/pexels-go/internal/atomicfile"
+)
+
+// checkpointFile is the name of the checkpoint within the export directory.
+const checkpointFile = "checkpoint.json"
+
+// checkpoint is the progress of an export as of the last row it saved. The
+// output files are cut back to Offsets when resuming, so rows written after
+// the checkpoint are not written twice.
+type checkpoint struct {
+	Cursor  pexels.Cursor    `json:"cursor"`
+	Seen    []string         `json:"seen"`
+	Offsets map[string]int64 `json:"offsets"`
+	Stats   Stats            `json:"stats"`
+	Done    bool             `json:"done"`
+}
+
+// loadCheckpoint reads the checkpoint within dir, returning false if there is
+// none.
+func loadCheckpoint(dir string) (checkpoint, bool, error) {
+	var cp checkpoint
+	data, err := os.ReadFile(filepath.Join(dir, checkpointFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return cp, false, nil
+	}
+	if err != nil {
+		return cp, false, fmt.Errorf("export: %w", err)
+	}
+	if err := json.Unmarshal(data, &cp); err != nil {
+		return cp, false, fmt.Errorf("export: reading checkpoint: %w", err)
+	}
+	return cp, true, nil
+}
+
+// save writes cp within dir, through a temporary file so a crash never leaves
+// half of one behind.
+func (cp checkpoint) save(dir string) error {
+	data, err := json.Marshal(cp)
+	if err != nil {
+		return fmt.Errorf("export: %w", err)
+	}
+	if err := atomicfile.WriteFile(
+		filepath.Join(dir, checkpointFile), data,
+	); err != nil {
+		return fmt.Errorf("export: %w", err)
+	}
+	return nil
+}
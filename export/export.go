@@ -0,0 +1,323 @@
+// Package export writes the results of a search or the media of a collection
+// to JSONL and CSV files to build datasets from.
+//
+// An export writes two tables: media, holding a MediaRecord for every photo
+// and video, and video_files, holding a VideoFileRecord for every file of the
+// exported videos. Every table is written to <table>.jsonl and <table>.csv
+// within the export directory, next to a checkpoint. When an export is
+// interrupted, running it again with the same Config continues after the last
+// page that was completely written.
+//
+//	stats, err := export.Run(ctx, client, export.Config{
+//		Dir:         "ocean",
+//		PhotoSearch: &pexels.PhotoSearchParams{Query: "ocean"},
+//		Limit:       10_000,
+//	})
+package export
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/j-mnr/pexels-go"
+)
+
+var (
+	ErrMissingDir         = errors.New("export: the output directory is required")
+	ErrNoSource           = errors.New("export: exactly one source must be set")
+	ErrCheckpointMismatch = errors.New("export: the directory holds a checkpoint of a different export")
+)
+
+// Config describes an export. Exactly one of PhotoSearch, VideoSearch and
+// Collection must be set. A zero PerPage in their params is raised to 80, the
+// most Pexels allows, to need as few requests as possible.
+type Config struct {
+	// Dir is the directory the files are written to, it is created if it
+	// does not exist.
+	Dir string
+
+	PhotoSearch *pexels.PhotoSearchParams
+	VideoSearch *pexels.VideoSearchParams
+	Collection  *pexels.CollectionMediaParams
+
+	// JSONL and CSV select the formats written. If neither is set both are.
+	JSONL bool
+	CSV   bool
+	// Limit stops the export after this many media were written, counting
+	// the runs it resumed from. Running it again with a higher Limit writes
+	// the rest. Zero or less means no limit.
+	Limit int
+	// Restart throws away the checkpoint and files of an earlier export in
+	// Dir instead of resuming it.
+	Restart bool
+	// Progress, if set, is called after every page that was written.
+	Progress func(Stats)
+}
+
+// Stats counts what an export did, including the runs it resumed from.
+type Stats struct {
+	Pages      int `json:"pages"`
+	Media      int `json:"media"`
+	VideoFiles int `json:"video_files"` //nolint:tagliatelle
+	// Duplicates is the number of media skipped because they were exported
+	// before, which happens when results shift between pages.
+	Duplicates int `json:"duplicates"`
+	// Skipped is the number of media that are neither a photo nor a video.
+	Skipped int `json:"skipped"`
+}
+
+const maxPerPage = 80
+
+// Run exports the media described by cfg, resuming an earlier export in
+// cfg.Dir if there is one. An export that already finished is not run again
+// unless cfg.Restart is set.
+func Run(ctx context.Context, c *pexels.Client, cfg Config) (Stats, error) {
+	if cfg.Dir == "" {
+		return Stats{}, ErrMissingDir
+	}
+	src, err := cfg.source(c)
+	if err != nil {
+		return Stats{}, err
+	}
+	if err := os.MkdirAll(cfg.Dir, 0o755); err != nil {
+		return Stats{}, fmt.Errorf("export: %w", err)
+	}
+
+	cp, found, err := loadCheckpoint(cfg.Dir)
+	if err != nil {
+		return Stats{}, err
+	}
+	var resume *pexels.Cursor
+	switch {
+	case !found || cfg.Restart:
+		cp = checkpoint{}
+	case cp.Cursor.Endpoint != src.Endpoint || cp.Cursor.Query != src.Query:
+		return cp.Stats, fmt.Errorf("%w: %s?%s",
+			ErrCheckpointMismatch, cp.Cursor.Endpoint, cp.Cursor.Query)
+	case cp.Done:
+		return cp.Stats, nil
+	default:
+		resume = &cp.Cursor
+	}
+
+	e, err := newExporter(cfg, cp)
+	if err != nil {
+		return cp.Stats, err
+	}
+	err = src.crawl(ctx, e, resume)
+	if closeErr := e.close(); err == nil {
+		err = closeErr
+	}
+	return e.stats, err
+}
+
+// source is what an export crawls.
+type source struct {
+	// Cursor is where a new export starts; its endpoint and query make sure
+	// a checkpoint is never resumed by a different export.
+	pexels.Cursor
+	// crawl runs the export, from resume if it is not nil.
+	crawl func(ctx context.Context, e *exporter, resume *pexels.Cursor) error
+}
+
+func (cfg Config) source(c *pexels.Client) (source, error) {
+	var sources []source
+	if cfg.PhotoSearch != nil {
+		params := *cfg.PhotoSearch
+		params.Page, params.PerPage = 0, perPage(params.PerPage)
+		sources = append(sources,
+			newSource(c, c.SearchPhotosPager(&params), photoMedia))
+	}
+	if cfg.VideoSearch != nil {
+		params := *cfg.VideoSearch
+		params.Page, params.PerPage = 0, perPage(params.PerPage)
+		sources = append(sources,
+			newSource(c, c.SearchVideosPager(&params), videoMedia))
+	}
+	if cfg.Collection != nil {
+		params := *cfg.Collection
+		params.Page, params.PerPage = 0, perPage(params.PerPage)
+		sources = append(sources, newSource(c, c.CollectionPager(&params),
+			func(m pexels.Media) pexels.Media { return m }))
+	}
+	if len(sources) != 1 {
+		return source{}, ErrNoSource
+	}
+	return sources[0], nil
+}
+
+func newSource[T any](
+	c *pexels.Client, fresh *pexels.Pager[T], media func(T) pexels.Media,
+) source {
+	return source{
+		Cursor: fresh.Cursor(),
+		crawl: func(ctx context.Context, e *exporter, resume *pexels.Cursor) error {
+			p := fresh
+			if resume != nil {
+				var err error
+				if p, err = pexels.ResumePager[T](c, *resume); err != nil {
+					return err
+				}
+			}
+			return crawl(ctx, e, p, media)
+		},
+	}
+}
+
+func perPage(n uint8) uint8 {
+	if n == 0 {
+		return maxPerPage
+	}
+	return n
+}
+
+func photoMedia(p pexels.Photo) pexels.Media { return &p }
+
+func videoMedia(v pexels.Video) pexels.Media { return &v }
+
+// crawl exports every item of p, saving a checkpoint whenever a page was
+// completely written.
+func crawl[T any](
+	ctx context.Context, e *exporter, p *pexels.Pager[T],
+	media func(T) pexels.Media,
+) error {
+	if e.full() {
+		return e.checkpoint(p.Cursor(), false)
+	}
+	for p.Next(ctx) {
+		if err := e.add(media(p.Item())); err != nil {
+			return err
+		}
+		if e.full() {
+			// Stopping at the Limit is not finishing: running the export
+			// again with a higher Limit continues from here.
+			return e.checkpoint(p.Cursor(), false)
+		}
+		if cur := p.Cursor(); len(cur.SeenIDs) == 0 {
+			e.stats.Pages++
+			if err := e.checkpoint(cur, false); err != nil {
+				return err
+			}
+		}
+	}
+	if err := p.Err(); err != nil {
+		return err
+	}
+	return e.checkpoint(p.Cursor(), true)
+}
+
+type exporter struct {
+	cfg   Config
+	stats Stats
+	seen  map[string]struct{}
+	media []*table
+	files []*table
+}
+
+func newExporter(cfg Config, cp checkpoint) (*exporter, error) {
+	e := &exporter{
+		cfg:   cfg,
+		stats: cp.Stats,
+		seen:  make(map[string]struct{}, len(cp.Seen)),
+	}
+	for _, key := range cp.Seen {
+		e.seen[key] = struct{}{}
+	}
+	var exts []string
+	if cfg.JSONL || !cfg.CSV {
+		exts = append(exts, ".jsonl")
+	}
+	if cfg.CSV || !cfg.JSONL {
+		exts = append(exts, ".csv")
+	}
+	for _, ext := range exts {
+		t, err := openTable(cfg.Dir, "media"+ext, cp.Offsets["media"+ext],
+			MediaColumns)
+		if err != nil {
+			e.close()
+			return nil, err
+		}
+		e.media = append(e.media, t)
+		if t, err = openTable(cfg.Dir, "video_files"+ext,
+			cp.Offsets["video_files"+ext], VideoFileColumns); err != nil {
+			e.close()
+			return nil, err
+		}
+		e.files = append(e.files, t)
+	}
+	return e, nil
+}
+
+// add writes the rows of m unless it was exported before.
+func (e *exporter) add(m pexels.Media) error {
+	r, files, ok := NewRecords(m)
+	if !ok {
+		e.stats.Skipped++
+		return nil
+	}
+	if _, dup := e.seen[r.key()]; dup {
+		e.stats.Duplicates++
+		return nil
+	}
+	e.seen[r.key()] = struct{}{}
+	for _, t := range e.media {
+		if err := t.write(r); err != nil {
+			return err
+		}
+	}
+	for _, f := range files {
+		for _, t := range e.files {
+			if err := t.write(f); err != nil {
+				return err
+			}
+		}
+	}
+	e.stats.Media++
+	e.stats.VideoFiles += len(files)
+	return nil
+}
+
+func (e *exporter) full() bool {
+	return e.cfg.Limit > 0 && e.stats.Media >= e.cfg.Limit
+}
+
+// checkpoint flushes all rows written so far and saves the progress along
+// with cur, the position of the Pager after the last written row.
+func (e *exporter) checkpoint(cur pexels.Cursor, done bool) error {
+	cp := checkpoint{
+		Cursor:  cur,
+		Seen:    make([]string, 0, len(e.seen)),
+		Offsets: map[string]int64{},
+		Stats:   e.stats,
+		Done:    done,
+	}
+	for key := range e.seen {
+		cp.Seen = append(cp.Seen, key)
+	}
+	sort.Strings(cp.Seen)
+	for _, t := range append(e.media[:len(e.media):len(e.media)], e.files...) {
+		n, err := t.sync()
+		if err != nil {
+			return err
+		}
+		cp.Offsets[t.name] = n
+	}
+	if err := cp.save(e.cfg.Dir); err != nil {
+		return err
+	}
+	if e.cfg.Progress != nil {
+		e.cfg.Progress(e.stats)
+	}
+	return nil
+}
+
+func (e *exporter) close() error {
+	var errs []error
+	for _, t := range append(e.media[:len(e.media):len(e.media)], e.files...) {
+		errs = append(errs, t.close())
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,124 @@
+package export_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/j-mnr/pexels-go"
+	"github.com/j-mnr/pexels-go/export"
+	"github.com/j-mnr/pexels-go/pexelstest"
+	"github.com/matryer/is"
+)
+
+var files = []string{
+	"media.jsonl", "media.csv", "video_files.jsonl", "video_files.csv",
+}
+
+func readFiles(t *testing.T, dir string) map[string]string {
+	t.Helper()
+	contents := map[string]string{}
+	for _, name := range files {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		is.New(t).NoErr(err)
+		contents[name] = string(data)
+	}
+	return contents
+}
+
+func TestRunResumesWhereItStopped(t *testing.T) {
+	srv := pexelstest.NewServer()
+	defer srv.Close()
+	c, err := srv.NewClient()
+	is.New(t).NoErr(err)
+	ctx := context.Background()
+	config := func(dir string) export.Config {
+		return export.Config{
+			Dir:         dir,
+			VideoSearch: &pexels.VideoSearchParams{Query: "ocean", PerPage: 3},
+		}
+	}
+
+	want := t.TempDir()
+	stats, err := export.Run(ctx, c, config(want))
+	is.New(t).NoErr(err)
+	is.New(t).Equal(stats, export.Stats{Pages: 4, Media: 10, VideoFiles: 50})
+
+	for _, stopAt := range []int{1, 3, 4, 9} {
+		t.Run("", func(t *testing.T) {
+			is := is.New(t)
+			dir := t.TempDir()
+			cfg := config(dir)
+			ctx, cancel := context.WithCancel(ctx)
+			defer cancel()
+			cfg.Progress = func(s export.Stats) {
+				if s.Media >= stopAt {
+					cancel()
+				}
+			}
+			_, err := export.Run(ctx, c, cfg)
+			is.True(errors.Is(err, context.Canceled))
+
+			stats, err := export.Run(context.Background(), c, config(dir))
+			is.NoErr(err)
+			is.Equal(stats.Media, 10)
+			is.Equal(stats.Duplicates, 0)
+			is.Equal(readFiles(t, dir), readFiles(t, want))
+		})
+	}
+}
+
+func TestRunLimitIsNotDone(t *testing.T) {
+	is := is.New(t)
+	srv := pexelstest.NewServer()
+	defer srv.Close()
+	c, err := srv.NewClient()
+	is.NoErr(err)
+	ctx := context.Background()
+	cfg := export.Config{
+		Dir:         t.TempDir(),
+		PhotoSearch: &pexels.PhotoSearchParams{Query: "ocean", PerPage: 5},
+		Limit:       7,
+	}
+
+	stats, err := export.Run(ctx, c, cfg)
+	is.NoErr(err)
+	is.Equal(stats.Media, 7)
+
+	cfg.Limit = 15
+	stats, err = export.Run(ctx, c, cfg)
+	is.NoErr(err)
+	is.Equal(stats.Media, 15)
+
+	cfg.Limit = 0
+	stats, err = export.Run(ctx, c, cfg)
+	is.NoErr(err)
+	is.Equal(stats.Media, 20)
+
+	// A finished export is not run again.
+	requests := srv.Requests()
+	stats, err = export.Run(ctx, c, cfg)
+	is.NoErr(err)
+	is.Equal(stats.Media, 20)
+	is.Equal(srv.Requests(), requests)
+}
+
+func TestRunCheckpointMismatch(t *testing.T) {
+	is := is.New(t)
+	srv := pexelstest.NewServer()
+	defer srv.Close()
+	c, err := srv.NewClient()
+	is.NoErr(err)
+	dir := t.TempDir()
+
+	_, err = export.Run(context.Background(), c, export.Config{
+		Dir: dir, PhotoSearch: &pexels.PhotoSearchParams{Query: "ocean"},
+	})
+	is.NoErr(err)
+	_, err = export.Run(context.Background(), c, export.Config{
+		Dir: dir, PhotoSearch: &pexels.PhotoSearchParams{Query: "forest"},
+	})
+	is.True(errors.Is(err, export.ErrCheckpointMismatch))
+}
@@ -0,0 +1,148 @@
+package export
+
+import (
+	"strconv"
+
+	"github.com/j-mnr/pexels-go"
+)
+
+// MediaRecord is a row of the media table. Photos and videos share the table;
+// fields that do not apply to the type of the row are left empty.
+//
+//nolint:tagliatelle
+type MediaRecord struct {
+	Type        string `json:"type"` // "photo" or "video"
+	ID          uint64 `json:"id"`
+	Width       uint16 `json:"width"`
+	Height      uint16 `json:"height"`
+	URL         string `json:"url"`
+	CreatorID   uint64 `json:"creator_id"`
+	CreatorName string `json:"creator_name"`
+	CreatorURL  string `json:"creator_url"`
+	// AvgColor and Src are only set for photos.
+	AvgColor string             `json:"avg_color"`
+	Src      pexels.PhotoSource `json:"src"`
+	// Duration, in seconds, and Image are only set for videos.
+	Duration uint16 `json:"duration"`
+	Image    string `json:"image"`
+}
+
+// VideoFileRecord is a row of the video_files table, one for every file of
+// every exported video.
+//
+//nolint:tagliatelle
+type VideoFileRecord struct {
+	VideoID  uint64 `json:"video_id"`
+	ID       uint64 `json:"id"`
+	Quality  string `json:"quality"`
+	FileType string `json:"file_type"`
+	Width    uint16 `json:"width"`
+	Height   uint16 `json:"height"`
+	Link     string `json:"link"`
+}
+
+// MediaColumns is the header of the media CSV file. The PhotoSource is
+// flattened into the src_ columns.
+var MediaColumns = []string{
+	"type", "id", "width", "height", "url",
+	"creator_id", "creator_name", "creator_url", "avg_color",
+	"src_original", "src_large2x", "src_large", "src_medium", "src_small",
+	"src_portrait", "src_landscape", "src_tiny",
+	"duration", "image",
+}
+
+// VideoFileColumns is the header of the video_files CSV file.
+var VideoFileColumns = []string{
+	"video_id", "id", "quality", "file_type", "width", "height", "link",
+}
+
+// NewRecords turns m into its rows. It reports false for media that is
+// neither a Photo nor a Video.
+func NewRecords(m pexels.Media) (MediaRecord, []VideoFileRecord, bool) {
+	switch v := m.(type) {
+	case *pexels.Photo:
+		return photoRecord(*v), nil, true
+	case pexels.Photo:
+		return photoRecord(v), nil, true
+	case *pexels.Video:
+		r, files := videoRecords(*v)
+		return r, files, true
+	case pexels.Video:
+		r, files := videoRecords(v)
+		return r, files, true
+	}
+	return MediaRecord{}, nil, false
+}
+
+func photoRecord(p pexels.Photo) MediaRecord {
+	return MediaRecord{
+		Type:        "photo",
+		ID:          p.ID,
+		Width:       p.Width,
+		Height:      p.Height,
+		URL:         p.URL,
+		CreatorID:   p.PhotographerID,
+		CreatorName: p.Photographer,
+		CreatorURL:  p.PhotographerURL,
+		AvgColor:    p.AvgColor,
+		Src:         p.Src,
+	}
+}
+
+func videoRecords(v pexels.Video) (MediaRecord, []VideoFileRecord) {
+	r := MediaRecord{
+		Type:        "video",
+		ID:          v.ID,
+		Width:       v.Width,
+		Height:      v.Height,
+		URL:         v.URL,
+		CreatorID:   v.User.ID,
+		CreatorName: v.User.Name,
+		CreatorURL:  v.User.URL,
+		Duration:    v.Duration,
+		Image:       v.Image,
+	}
+	files := make([]VideoFileRecord, len(v.VideoFiles))
+	for i, f := range v.VideoFiles {
+		files[i] = VideoFileRecord{
+			VideoID:  v.ID,
+			ID:       f.ID,
+			Quality:  f.Quality,
+			FileType: f.FileType,
+			Width:    f.Width,
+			Height:   f.Height,
+			Link:     f.Link,
+		}
+	}
+	return r, files
+}
+
+func (r MediaRecord) csvRow() []string {
+	var duration string
+	if r.Type == "video" {
+		duration = itoa(r.Duration)
+	}
+	return []string{
+		r.Type, itoa(r.ID), itoa(r.Width), itoa(r.Height), r.URL,
+		itoa(r.CreatorID), r.CreatorName, r.CreatorURL, r.AvgColor,
+		r.Src.Original, r.Src.Large2x, r.Src.Large, r.Src.Medium, r.Src.Small,
+		r.Src.Portrait, r.Src.Landscape, r.Src.Tiny,
+		duration, r.Image,
+	}
+}
+
+func (r VideoFileRecord) csvRow() []string {
+	return []string{
+		itoa(r.VideoID), itoa(r.ID), r.Quality, r.FileType,
+		itoa(r.Width), itoa(r.Height), r.Link,
+	}
+}
+
+// key identifies the media of r across both types.
+func (r MediaRecord) key() string {
+	return r.Type + ":" + itoa(r.ID)
+}
+
+func itoa[T ~uint16 | ~uint64](v T) string {
+	return strconv.FormatUint(uint64(v), 10)
+}
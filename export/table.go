@@ -0,0 +1,105 @@
+package export
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// table is one output file holding rows of a single table in one format.
+type table struct {
+	name string
+	f    *os.File
+	cw   *countingWriter
+	buf  *bufio.Writer
+	enc  *json.Encoder
+	csv  *csv.Writer
+}
+
+type csvRower interface{ csvRow() []string }
+
+// openTable opens the file name within dir, throwing away everything past
+// offset, which is where the last checkpoint left it.
+func openTable(dir, name string, offset int64, header []string) (*table, error) {
+	f, err := os.OpenFile(filepath.Join(dir, name), os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("export: %w", err)
+	}
+	if err := f.Truncate(offset); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("export: %w", err)
+	}
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("export: %w", err)
+	}
+	t := &table{name: name, f: f, cw: &countingWriter{w: f, n: offset}}
+	if filepath.Ext(name) == ".csv" {
+		t.csv = csv.NewWriter(t.cw)
+		if offset == 0 {
+			if err := t.csv.Write(header); err != nil {
+				f.Close()
+				return nil, fmt.Errorf("export: %w", err)
+			}
+		}
+		return t, nil
+	}
+	t.buf = bufio.NewWriter(t.cw)
+	t.enc = json.NewEncoder(t.buf)
+	t.enc.SetEscapeHTML(false)
+	return t, nil
+}
+
+func (t *table) write(row csvRower) error {
+	var err error
+	if t.csv != nil {
+		err = t.csv.Write(row.csvRow())
+	} else {
+		err = t.enc.Encode(row)
+	}
+	if err != nil {
+		return fmt.Errorf("export: %w", err)
+	}
+	return nil
+}
+
+// sync flushes the buffered rows to disk and returns the size of the file.
+func (t *table) sync() (int64, error) {
+	var err error
+	if t.csv != nil {
+		t.csv.Flush()
+		err = t.csv.Error()
+	} else {
+		err = t.buf.Flush()
+	}
+	if err == nil {
+		err = t.f.Sync()
+	}
+	if err != nil {
+		return 0, fmt.Errorf("export: %w", err)
+	}
+	return t.cw.n, nil
+}
+
+func (t *table) close() error {
+	_, err := t.sync()
+	if closeErr := t.f.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("export: %w", closeErr)
+	}
+	return err
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
@@ -1,14 +1,29 @@
 package pexels
 
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+var (
+	ErrInvalidHexColor = errors.New("a hex color must be 3 or 6 hexadecimal digits")
+	ErrUnknownEnum     = errors.New("unknown enum value")
+)
+
 // Locale is an enum; all of them start with "Locale".
 type Locale interface {
 	locale()
+	String() string
 }
 
 type locale string
 
 func (locale) locale() {}
 
+func (v locale) String() string { return string(v) }
+
 const (
 	LocaleEN_US locale = "en-US"
 	LocalePT_BR locale = "pt-BR"
@@ -43,12 +58,15 @@ const (
 // Size is an enum; all of them start with "Size".
 type Size interface {
 	size()
+	String() string
 }
 
 type size string
 
 func (size) size() {}
 
+func (v size) String() string { return string(v) }
+
 const (
 	SizeSmall  size = "small"
 	SizeMedium size = "medium"
@@ -58,18 +76,69 @@ const (
 // Orientation is an enum; all of them start with "Orientation".
 type Orientation interface {
 	orientation()
+	String() string
 }
 
 type orientation string
 
 func (orientation) orientation() {}
 
+func (v orientation) String() string { return string(v) }
+
 const (
-	OrientationLandscape = "landscape"
-	OrientationPortrait  = "portrait"
-	OrientationSquare    = "square"
+	OrientationLandscape orientation = "landscape"
+	OrientationPortrait  orientation = "portrait"
+	OrientationSquare    orientation = "square"
 )
 
+// Color is an enum; all of them start with "Color". Any other color can be
+// searched for by its hexadecimal code with HexColor.
+type Color interface {
+	color()
+	String() string
+}
+
+type color string
+
+func (color) color() {}
+
+func (v color) String() string { return string(v) }
+
+const (
+	ColorRed       color = "red"
+	ColorOrange    color = "orange"
+	ColorYellow    color = "yellow"
+	ColorGreen     color = "green"
+	ColorTurquoise color = "turquoise"
+	ColorBlue      color = "blue"
+	ColorViolet    color = "violet"
+	ColorPink      color = "pink"
+	ColorBrown     color = "brown"
+	ColorBlack     color = "black"
+	ColorGray      color = "gray"
+	ColorWhite     color = "white"
+)
+
+// HexColor returns the Color of a hexadecimal code such as "#ffffff". The
+// leading # is optional and the three digit shorthand "#fff" is expanded.
+func HexColor(hex string) (Color, error) {
+	digits := strings.ToLower(strings.TrimPrefix(hex, "#"))
+	if len(digits) != 3 && len(digits) != 6 {
+		return nil, ErrInvalidHexColor
+	}
+	for _, r := range digits {
+		if !strings.ContainsRune("0123456789abcdef", r) {
+			return nil, ErrInvalidHexColor
+		}
+	}
+	if len(digits) == 3 {
+		digits = string([]byte{
+			digits[0], digits[0], digits[1], digits[1], digits[2], digits[2],
+		})
+	}
+	return color("#" + digits), nil
+}
+
 // Pagination is a common response struct for many endpoints that details how
 // to go to the previous and next pages as well as the total number of results,
 // what page you are on currently and how many results per page.
@@ -86,7 +155,95 @@ type Pagination struct {
 
 // General is the common parameters found between video and photo queries.
 type General struct {
-	Locale      Locale      `query:"locale"`
-	Orientation Orientation `query:"orientation"`
-	Size        Size        `query:"size"`
+	Locale      Locale
+	Orientation Orientation
+	Size        Size
+}
+
+func (g General) encode(q url.Values) {
+	setEnum(q, "locale", g.Locale)
+	setEnum(q, "orientation", g.Orientation)
+	setEnum(q, "size", g.Size)
+}
+
+var (
+	locales = []locale{
+		LocaleEN_US, LocalePT_BR, LocaleES_ES, LocaleCA_ES, LocaleDE_DE,
+		LocaleIT_IT, LocaleFR_FR, LocaleSV_SE, LocaleID_ID, LocalePL_PL,
+		LocaleJA_JP, LocaleZH_TW, LocaleZH_CN, LocaleKO_KR, LocaleTH_TH,
+		LocaleNL_NL, LocaleHU_HU, LocaleVI_VN, LocaleCS_CZ, LocaleDA_DK,
+		LocaleFI_FI, LocaleUK_UA, LocaleEL_GR, LocaleRO_RO, LocaleNB_NO,
+		LocaleSK_SK, LocaleTR_TR, LocaleRU_RU,
+	}
+	sizes        = []size{SizeSmall, SizeMedium, SizeLarge}
+	orientations = []orientation{
+		OrientationLandscape, OrientationPortrait, OrientationSquare,
+	}
+	colors = []color{
+		ColorRed, ColorOrange, ColorYellow, ColorGreen, ColorTurquoise,
+		ColorBlue, ColorViolet, ColorPink, ColorBrown, ColorBlack, ColorGray,
+		ColorWhite,
+	}
+)
+
+// ParseLocale returns the Locale for s, e.g. "en-US". The match is case
+// insensitive and an underscore may be used in place of the dash.
+func ParseLocale(s string) (Locale, error) {
+	l, err := parseEnum("locale", strings.ReplaceAll(s, "_", "-"), locales)
+	if err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// ParseSize returns the Size for s, e.g. "large". The match is case
+// insensitive.
+func ParseSize(s string) (Size, error) {
+	sz, err := parseEnum("size", s, sizes)
+	if err != nil {
+		return nil, err
+	}
+	return sz, nil
+}
+
+// ParseOrientation returns the Orientation for s, e.g. "portrait". The match
+// is case insensitive.
+func ParseOrientation(s string) (Orientation, error) {
+	o, err := parseEnum("orientation", s, orientations)
+	if err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// ParseColor returns the Color for s, which is either one of the named colors,
+// e.g. "turquoise", or a hexadecimal code accepted by HexColor.
+func ParseColor(s string) (Color, error) {
+	if c, err := parseEnum("color", s, colors); err == nil {
+		return c, nil
+	}
+	if c, err := HexColor(strings.TrimSpace(s)); err == nil {
+		return c, nil
+	}
+	return nil, fmt.Errorf("pexels: %w: color %q must be one of %s or a hex code",
+		ErrUnknownEnum, s, joinEnum(colors))
+}
+
+func parseEnum[T ~string](kind, s string, values []T) (T, error) {
+	s = strings.TrimSpace(s)
+	for _, v := range values {
+		if strings.EqualFold(string(v), s) {
+			return v, nil
+		}
+	}
+	return "", fmt.Errorf("pexels: %w: %s %q must be one of %s",
+		ErrUnknownEnum, kind, s, joinEnum(values))
+}
+
+func joinEnum[T ~string](values []T) string {
+	names := make([]string, len(values))
+	for i, v := range values {
+		names[i] = string(v)
+	}
+	return strings.Join(names, ", ")
 }
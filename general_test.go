@@ -0,0 +1,120 @@
+package pexels_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/j-mnr/pexels-go"
+	"github.com/matryer/is"
+)
+
+func TestHexColor(t *testing.T) {
+	tests := map[string]struct {
+		hex     string
+		want    string
+		wantErr bool
+	}{
+		"six digits":      {hex: "#ffffff", want: "#ffffff"},
+		"upper case":      {hex: "#A1B2C3", want: "#a1b2c3"},
+		"short":           {hex: "#abc", want: "#aabbcc"},
+		"missing #":       {hex: "a1b2c3", want: "#a1b2c3"},
+		"short missing #": {hex: "fff", want: "#ffffff"},
+		"non-hex":         {hex: "#gggggg", wantErr: true},
+		"named color":     {hex: "red", wantErr: true},
+		"two #":           {hex: "##fff", wantErr: true},
+		"four digits":     {hex: "#ffff", wantErr: true},
+		"seven digits":    {hex: "#fffffff", wantErr: true},
+		"empty":           {hex: "", wantErr: true},
+		"only #":          {hex: "#", wantErr: true},
+	}
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			is := is.New(t)
+			c, err := pexels.HexColor(tc.hex)
+			if tc.wantErr {
+				is.True(errors.Is(err, pexels.ErrInvalidHexColor))
+				is.Equal(c, nil)
+				return
+			}
+			is.NoErr(err)
+			is.Equal(c.String(), tc.want)
+		})
+	}
+}
+
+func TestHexColorEncode(t *testing.T) {
+	is := is.New(t)
+	c, err := pexels.HexColor("fff")
+	is.NoErr(err)
+	p := pexels.PhotoSearchParams{Query: "sea", Color: c}
+	is.Equal(p.Encode(), "color=%23ffffff&page=1&per_page=15&query=sea")
+}
+
+func TestParseEnums(t *testing.T) {
+	locale := func(s string) (fmt.Stringer, error) { return pexels.ParseLocale(s) }
+	size := func(s string) (fmt.Stringer, error) { return pexels.ParseSize(s) }
+	orientation := func(s string) (fmt.Stringer, error) {
+		return pexels.ParseOrientation(s)
+	}
+	color := func(s string) (fmt.Stringer, error) { return pexels.ParseColor(s) }
+
+	tests := map[string]struct {
+		parse   func(string) (fmt.Stringer, error)
+		in      string
+		want    string
+		wantErr string // the start of the error message
+	}{
+		"locale":              {parse: locale, in: "en-US", want: "en-US"},
+		"locale any case":     {parse: locale, in: "PT-br", want: "pt-BR"},
+		"locale underscore":   {parse: locale, in: "en_US", want: "en-US"},
+		"locale whitespace":   {parse: locale, in: " ja_jp\n", want: "ja-JP"},
+		"size":                {parse: size, in: "LARGE", want: "large"},
+		"size whitespace":     {parse: size, in: "\tsmall ", want: "small"},
+		"orientation":         {parse: orientation, in: "Portrait", want: "portrait"},
+		"color":               {parse: color, in: "Turquoise", want: "turquoise"},
+		"color hex":           {parse: color, in: " #FFF ", want: "#ffffff"},
+		"color hex without #": {parse: color, in: "a1b2c3", want: "#a1b2c3"},
+		"unknown locale": {
+			parse: locale, in: " english ",
+			wantErr: `pexels: unknown enum value: locale "english" must be one of en-US, pt-BR, `,
+		},
+		"unknown size": {
+			parse: size, in: "huge",
+			wantErr: `pexels: unknown enum value: size "huge" must be one of small, medium, large`,
+		},
+		"unknown orientation": {
+			parse: orientation, in: "diagonal",
+			wantErr: `pexels: unknown enum value: orientation "diagonal" must be one of landscape, portrait, square`,
+		},
+		"empty orientation": {
+			parse: orientation, in: "",
+			wantErr: `pexels: unknown enum value: orientation ""`,
+		},
+		"unknown color": {
+			parse: color, in: "magenta",
+			wantErr: `pexels: unknown enum value: color "magenta" must be one of red, orange, `,
+		},
+		"bad hex color": {
+			parse: color, in: "#ggg",
+			wantErr: `pexels: unknown enum value: color "#ggg" must be one of `,
+		},
+	}
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			is := is.New(t)
+			got, err := tc.parse(tc.in)
+			if tc.wantErr != "" {
+				is.True(errors.Is(err, pexels.ErrUnknownEnum))
+				is.True(strings.HasPrefix(err.Error(), tc.wantErr))
+				is.Equal(got, nil)
+				return
+			}
+			is.NoErr(err)
+			is.Equal(got.String(), tc.want)
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package pexels
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// RequestInfo describes a single attempt at sending a request to Pexels.
+type RequestInfo struct {
+	Method string
+	// URL is the full URL of the request with any credentials redacted. The
+	// API key itself is sent in a header and never part of it.
+	URL string
+	// Attempt counts the attempts at the request, starting at 1. It only goes
+	// up when the request is retried.
+	Attempt int
+}
+
+// ResponseInfo describes the outcome of an attempt.
+type ResponseInfo struct {
+	RequestInfo
+	// StatusCode is zero when no response was received, Err says why.
+	StatusCode int
+	Duration   time.Duration
+	// RateLimitRemaining is the X-Ratelimit-Remaining header, or -1 if the
+	// response did not have one.
+	RateLimitRemaining int
+	Err                error
+}
+
+// Hooks are called around every attempt at a request sent to Pexels,
+// including retries. Responses served from the Cache or shared by coalesced
+// requests are not sent and do not call them. Hooks are called from the
+// goroutine making the request and thus may run concurrently, e.g. within
+// GetPhotos. Either function may be nil.
+type Hooks struct {
+	Before func(ctx context.Context, info RequestInfo)
+	After  func(ctx context.Context, info ResponseInfo)
+}
+
+// WithHooks adds h to the Hooks of the Client. Hooks are called in the order
+// they were added.
+func WithHooks(h Hooks) Option {
+	return func(cl *Client) { cl.hooks = append(cl.hooks, h) }
+}
+
+// WithLogger logs every attempt at a request to l: successful ones at debug
+// level, failed ones at warn level. A nil l logs to slog.Default.
+func WithLogger(l *slog.Logger) Option {
+	if l == nil {
+		l = slog.Default()
+	}
+	return WithHooks(Hooks{After: func(ctx context.Context, info ResponseInfo) {
+		level := slog.LevelDebug
+		if info.Err != nil || info.StatusCode >= 400 {
+			level = slog.LevelWarn
+		}
+		if !l.Enabled(ctx, level) {
+			return
+		}
+		attrs := []slog.Attr{
+			slog.String("method", info.Method),
+			slog.String("url", info.URL),
+			slog.Int("attempt", info.Attempt),
+			slog.Duration("duration", info.Duration),
+		}
+		if info.StatusCode != 0 {
+			attrs = append(attrs, slog.Int("status", info.StatusCode))
+		}
+		if info.RateLimitRemaining >= 0 {
+			attrs = append(attrs,
+				slog.Int("rate_limit_remaining", info.RateLimitRemaining))
+		}
+		if info.Err != nil {
+			attrs = append(attrs, slog.String("error", info.Err.Error()))
+		}
+		l.LogAttrs(ctx, level, "pexels request", attrs...)
+	}})
+}
+
+func (c *Client) before(ctx context.Context, info RequestInfo) {
+	for _, h := range c.hooks {
+		if h.Before != nil {
+			h.Before(ctx, info)
+		}
+	}
+}
+
+func (c *Client) after(
+	ctx context.Context, info RequestInfo, start time.Time,
+	resp *http.Response, err error,
+) {
+	if len(c.hooks) == 0 {
+		return
+	}
+	ri := ResponseInfo{
+		RequestInfo:        info,
+		Duration:           time.Since(start),
+		RateLimitRemaining: -1,
+		Err:                err,
+	}
+	if resp != nil {
+		ri.StatusCode = resp.StatusCode
+		if resp.Header.Get("X-Ratelimit-Remaining") != "" {
+			rc := ResponseCommon{Header: resp.Header}
+			ri.RateLimitRemaining = rc.GetRateLimitRemaining()
+		}
+	}
+	for _, h := range c.hooks {
+		if h.After != nil {
+			h.After(ctx, ri)
+		}
+	}
+}
+
+// redactedParams are query parameters that could hold credentials.
+var redactedParams = []string{"key", "api_key", "apikey", "token", "access_token"}
+
+// redactURL returns u as a string with any credentials replaced.
+func redactURL(u *url.URL) string {
+	r := *u
+	if r.User != nil {
+		r.User = url.User("REDACTED")
+	}
+	if r.RawQuery != "" {
+		q := r.Query()
+		changed := false
+		for name := range q {
+			for _, p := range redactedParams {
+				if strings.EqualFold(name, p) {
+					q.Set(name, "REDACTED")
+					changed = true
+				}
+			}
+		}
+		if changed {
+			r.RawQuery = q.Encode()
+		}
+	}
+	return r.String()
+}
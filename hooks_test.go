@@ -0,0 +1,67 @@
+package pexels_test
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/j-mnr/pexels-go"
+	"github.com/j-mnr/pexels-go/pexelstest"
+	"github.com/matryer/is"
+)
+
+func TestHooksCalledPerAttempt(t *testing.T) {
+	is := is.New(t)
+	srv := pexelstest.NewServer()
+	defer srv.Close()
+
+	var mu sync.Mutex
+	var before []pexels.RequestInfo
+	var after []pexels.ResponseInfo
+	c, err := srv.NewClient(
+		pexels.WithRetry(pexels.RetryPolicy{
+			MaxAttempts: 3, BaseBackoff: time.Millisecond,
+		}),
+		pexels.WithHooks(pexels.Hooks{
+			Before: func(_ context.Context, info pexels.RequestInfo) {
+				mu.Lock()
+				defer mu.Unlock()
+				before = append(before, info)
+			},
+			After: func(_ context.Context, info pexels.ResponseInfo) {
+				mu.Lock()
+				defer mu.Unlock()
+				after = append(after, info)
+			},
+		}),
+	)
+	is.NoErr(err)
+
+	srv.FailNext(1, http.StatusServiceUnavailable)
+	_, err = c.GetPhoto(context.Background(), srv.Photos[0].ID)
+	is.NoErr(err)
+
+	is.Equal(len(before), 2)
+	is.Equal(len(after), 2)
+	for i, info := range after {
+		is.Equal(info.RequestInfo, before[i])
+		is.Equal(info.Attempt, i+1)
+		is.Equal(info.Method, http.MethodGet)
+		is.True(info.RateLimitRemaining > 0)
+	}
+	is.Equal(after[0].StatusCode, http.StatusServiceUnavailable)
+	is.Equal(after[1].StatusCode, http.StatusOK)
+}
+
+func TestWithNilLogger(t *testing.T) {
+	is := is.New(t)
+	srv := pexelstest.NewServer()
+	defer srv.Close()
+	c, err := srv.NewClient(pexels.WithLogger(nil))
+	is.NoErr(err)
+
+	_, err = c.GetPhoto(context.Background(), srv.Photos[0].ID)
+	is.NoErr(err)
+}
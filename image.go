@@ -0,0 +1,224 @@
+package pexels
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Fit is an enum; all of them start with "Fit". It is how a photo is made to
+// fit the width and height asked for.
+type Fit interface {
+	fit()
+	String() string
+}
+
+type fit string
+
+func (fit) fit() {}
+
+func (v fit) String() string { return string(v) }
+
+const (
+	FitCrop  fit = "crop"
+	FitClip  fit = "clip"
+	FitFill  fit = "fill"
+	FitMax   fit = "max"
+	FitMin   fit = "min"
+	FitScale fit = "scale"
+)
+
+// Format is an enum; all of them start with "Format". It is the image format
+// a photo is served as.
+type Format interface {
+	format()
+	String() string
+}
+
+type format string
+
+func (format) format() {}
+
+func (v format) String() string { return string(v) }
+
+const (
+	FormatJPG  format = "jpg"
+	FormatPNG  format = "png"
+	FormatWebP format = "webp"
+	FormatAVIF format = "avif"
+)
+
+// ImageOptions are the parameters Pexels accepts to serve a photo at any size.
+// Every zero field is left out.
+type ImageOptions struct {
+	Width  uint16
+	Height uint16
+	DPR    float64 // Device pixel ratio, e.g. 2 for retina screens.
+	Fit    Fit
+	// Compress serves a compressed photo in the sRGB color space, like all of
+	// the variants in PhotoSource.
+	Compress bool
+	Format   Format
+}
+
+// Resize returns the URL of the original photo served as described by opts.
+func (ps PhotoSource) Resize(opts ImageOptions) string {
+	if ps.Original == "" {
+		return ""
+	}
+	q := url.Values{}
+	if opts.Compress {
+		q.Set("auto", "compress")
+		q.Set("cs", "tinysrgb")
+	}
+	setUint(q, "w", opts.Width, 0)
+	setUint(q, "h", opts.Height, 0)
+	if opts.DPR > 0 {
+		q.Set("dpr", strconv.FormatFloat(opts.DPR, 'f', -1, 64))
+	}
+	setEnum(q, "fit", opts.Fit)
+	setEnum(q, "fm", opts.Format)
+	if len(q) == 0 {
+		return ps.Original
+	}
+	return ps.Original + "?" + q.Encode()
+}
+
+// Srcset returns the value of a srcset attribute with a URL for every width,
+// such as "...?w=320 320w, ...?w=640 640w". When opts has both a Width and a
+// Height, the height of every URL is scaled to keep the same aspect ratio.
+func (ps PhotoSource) Srcset(widths []uint16, opts ImageOptions) string {
+	candidates := make([]string, 0, len(widths))
+	for _, w := range widths {
+		candidates = append(candidates,
+			fmt.Sprintf("%s %dw", ps.Resize(opts.scaledTo(w)), w))
+	}
+	return strings.Join(candidates, ", ")
+}
+
+// DensitySrcset returns the value of a srcset attribute with a URL for every
+// device pixel ratio in dprs, such as "...?dpr=1 1x, ...?dpr=2 2x".
+func (ps PhotoSource) DensitySrcset(dprs []float64, opts ImageOptions) string {
+	candidates := make([]string, 0, len(dprs))
+	for _, dpr := range dprs {
+		opts.DPR = dpr
+		candidates = append(candidates, fmt.Sprintf("%s %sx",
+			ps.Resize(opts), strconv.FormatFloat(dpr, 'f', -1, 64)))
+	}
+	return strings.Join(candidates, ", ")
+}
+
+func (opts ImageOptions) scaledTo(width uint16) ImageOptions {
+	if opts.Width != 0 && opts.Height != 0 {
+		opts.Height = uint16(uint32(opts.Height) * uint32(width) / uint32(opts.Width))
+	}
+	opts.Width = width
+	return opts
+}
+
+// DefaultSrcsetWidths are the widths used by Photo.Picture when
+// PictureOptions.Widths is empty.
+var DefaultSrcsetWidths = []uint16{320, 640, 960, 1280, 1920}
+
+// PictureOptions tweak the markup made by Photo.Picture.
+type PictureOptions struct {
+	// Widths are the widths offered in every srcset. Default:
+	// DefaultSrcsetWidths
+	Widths []uint16
+	// Sizes is the sizes attribute telling the browser how wide the image is
+	// displayed. Default: "100vw"
+	Sizes string
+	// Formats are offered as <source> elements, in order, before falling back
+	// to the <img> in JPEG, e.g. FormatAVIF and FormatWebP.
+	Formats []Format
+	// Image is applied to every URL, its Width is replaced for each of the
+	// Widths.
+	Image ImageOptions
+	// Alt is the alt text of the image. Default: "Photo by <Photographer>"
+	Alt   string
+	Class string
+	// Lazy defers loading the image until it is about to be scrolled into
+	// view.
+	Lazy bool
+}
+
+var pictureTmpl = template.Must(template.New("picture").Parse(
+	`<picture>` +
+		`{{range .Sources}}<source type="{{.Type}}" srcset="{{.Srcset}}" sizes="{{$.Sizes}}">{{end}}` +
+		`<img src="{{.Src}}" srcset="{{.Srcset}}" sizes="{{.Sizes}}"` +
+		`{{if .Width}} width="{{.Width}}" height="{{.Height}}"{{end}}` +
+		` alt="{{.Alt}}"{{if .Class}} class="{{.Class}}"{{end}}` +
+		`{{if .Lazy}} loading="lazy" decoding="async"{{end}}>` +
+		`</picture>`))
+
+// Picture returns <picture> markup for p, offering every width of opts in
+// every format, which is safe to use as is within an html/template.
+func (p Photo) Picture(opts PictureOptions) (template.HTML, error) {
+	widths := opts.Widths
+	if len(widths) == 0 {
+		widths = DefaultSrcsetWidths
+	}
+	sizes := opts.Sizes
+	if sizes == "" {
+		sizes = "100vw"
+	}
+	alt := opts.Alt
+	if alt == "" && p.Photographer != "" {
+		alt = "Photo by " + p.Photographer
+	}
+
+	type source struct {
+		Type   string
+		Srcset string
+	}
+	data := struct {
+		Sources       []source
+		Src, Srcset   string
+		Sizes         string
+		Width, Height uint16
+		Alt, Class    string
+		Lazy          bool
+	}{
+		Sizes: sizes,
+		Alt:   alt,
+		Class: opts.Class,
+		Lazy:  opts.Lazy,
+	}
+	for _, f := range opts.Formats {
+		img := opts.Image
+		img.Format = f
+		data.Sources = append(data.Sources, source{
+			Type:   mimeType(f),
+			Srcset: p.Src.Srcset(widths, img),
+		})
+	}
+	img := opts.Image
+	img.Format = nil
+	data.Srcset = p.Src.Srcset(widths, img)
+	last := widths[len(widths)-1]
+	data.Src = p.Src.Resize(img.scaledTo(last))
+	if p.Width != 0 && p.Height != 0 {
+		data.Width = last
+		data.Height = uint16(uint32(p.Height) * uint32(last) / uint32(p.Width))
+		if img.Width != 0 && img.Height != 0 {
+			data.Height = img.scaledTo(last).Height
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := pictureTmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf(wrapFmt, err)
+	}
+	return template.HTML(buf.String()), nil //nolint:gosec
+}
+
+// mimeType is the type of a <source> element serving the Format.
+func mimeType(f Format) string {
+	if f == FormatJPG {
+		return "image/jpeg"
+	}
+	return "image/" + f.String()
+}
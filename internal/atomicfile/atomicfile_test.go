@@ -0,0 +1,31 @@
+package atomicfile_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/j-mnr/pexels-go/internal/atomicfile"
+	"github.com/matryer/is"
+)
+
+func TestWriteFile(t *testing.T) {
+	is := is.New(t)
+	dir := t.TempDir()
+	path := filepath.Join(dir, "state.json")
+
+	is.NoErr(atomicfile.WriteFile(path, []byte("first")))
+	is.NoErr(atomicfile.WriteFile(path, []byte("second")))
+	data, err := os.ReadFile(path)
+	is.NoErr(err)
+	is.Equal(string(data), "second")
+
+	// No temporary file is left behind, even when the rename fails.
+	is.True(atomicfile.WriteFile(filepath.Join(dir, "missing", "x"), nil) != nil)
+	is.NoErr(os.Mkdir(filepath.Join(dir, "taken"), 0o700))
+	is.NoErr(os.WriteFile(filepath.Join(dir, "taken", "x"), nil, 0o600))
+	is.True(atomicfile.WriteFile(filepath.Join(dir, "taken"), []byte("x")) != nil)
+	entries, err := os.ReadDir(dir)
+	is.NoErr(err)
+	is.Equal(len(entries), 2)
+}
@@ -0,0 +1,372 @@
+// Package mirror keeps an offline copy of your collections in a directory.
+//
+// Every Sync walks all of your collections and their media and lays them out
+// as
+//
+//	state.json                        what the last Sync saw
+//	report.json                       the changes found by the last Sync
+//	collections/<id>/collection.json  the pexels.Collection
+//	collections/<id>/media.json       its media as a pexels.MediaPayload
+//	assets/photos/<id>.<ext>          downloaded photos, if enabled
+//	assets/videos/<id>.<ext>          downloaded videos, if enabled
+//
+// Assets are shared between collections and only downloaded once.
+package mirror
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/j-mnr/pexels-go"
+)
+
+var ErrMissingDir = errors.New("mirror: the mirror directory is required")
+
+// Config describes how a mirror is synced.
+type Config struct {
+	// Dir is the directory holding the mirror, it is created if it does not
+	// exist.
+	Dir string
+	// Assets downloads the photos and videos along with their metadata.
+	Assets bool
+	// PhotoVariant is the size of the photos downloaded. Default:
+	// pexels.VariantOriginal
+	PhotoVariant pexels.PhotoVariant
+	// VideoFile picks the file downloaded for every video with
+	// Video.BestFile. Videos without a matching file are not downloaded.
+	VideoFile pexels.FileConstraints
+	// Prune removes the assets of media that are no longer in any
+	// collection.
+	Prune bool
+}
+
+// Report is the change report of a Sync: everything that was added to or
+// removed from the mirror since the last Sync.
+//
+//nolint:tagliatelle
+type Report struct {
+	Started            time.Time           `json:"started"`
+	Finished           time.Time           `json:"finished"`
+	AddedCollections   []pexels.Collection `json:"added_collections"`
+	RemovedCollections []pexels.Collection `json:"removed_collections"`
+	// Changed lists every collection whose details or media changed,
+	// including the ones that were added or removed.
+	Changed []CollectionChange `json:"changed"`
+	// Downloaded and Pruned count the assets written and removed.
+	Downloaded int `json:"downloaded"`
+	Pruned     int `json:"pruned"`
+}
+
+// CollectionChange is how a single collection changed.
+type CollectionChange struct {
+	Collection pexels.Collection `json:"collection"`
+	// Details is set when the title, description or privacy changed.
+	Details bool       `json:"details"`
+	Added   []MediaRef `json:"added"`
+	Removed []MediaRef `json:"removed"`
+}
+
+// Empty reports whether nothing changed.
+func (r Report) Empty() bool { return len(r.Changed) == 0 }
+
+// String lists the changes one per line: a collection or media that was added
+// starts with a "+", one that was removed with a "-" and a collection whose
+// details changed with a "~".
+func (r Report) String() string {
+	var b strings.Builder
+	added := map[string]bool{}
+	for _, c := range r.AddedCollections {
+		added[c.ID] = true
+		fmt.Fprintf(&b, "+ collection %s %q\n", c.ID, c.Title)
+	}
+	removed := map[string]bool{}
+	for _, c := range r.RemovedCollections {
+		removed[c.ID] = true
+		fmt.Fprintf(&b, "- collection %s %q\n", c.ID, c.Title)
+	}
+	for _, ch := range r.Changed {
+		id := ch.Collection.ID
+		if ch.Details && !added[id] && !removed[id] {
+			fmt.Fprintf(&b, "~ collection %s %q\n", id, ch.Collection.Title)
+		}
+		for _, m := range ch.Added {
+			fmt.Fprintf(&b, "+ %s %s in %s\n", m.Type, m.ID, id)
+		}
+		for _, m := range ch.Removed {
+			fmt.Fprintf(&b, "- %s %s in %s\n", m.Type, m.ID, id)
+		}
+	}
+	return b.String()
+}
+
+// Sync brings the mirror in cfg.Dir up to date with your collections and
+// reports what changed since the last Sync. The state of the mirror is only
+// saved once everything was synced, so a failed Sync is simply run again.
+func Sync(ctx context.Context, c *pexels.Client, cfg Config) (Report, error) {
+	report := Report{Started: time.Now().UTC()}
+	if cfg.Dir == "" {
+		return report, ErrMissingDir
+	}
+	if cfg.PhotoVariant == nil {
+		cfg.PhotoVariant = pexels.VariantOriginal
+	}
+	old, err := LoadState(cfg.Dir)
+	if err != nil {
+		return report, err
+	}
+	for _, dir := range []string{"collections", "assets/photos", "assets/videos"} {
+		if err := os.MkdirAll(filepath.Join(cfg.Dir, dir), 0o755); err != nil {
+			return report, fmt.Errorf("mirror: %w", err)
+		}
+	}
+
+	s := &syncer{cfg: cfg, client: c, report: &report, assets: map[string]string{}}
+	for _, cs := range old.Collections {
+		for _, ref := range cs.Media {
+			if ref.Asset != "" {
+				s.assets[ref.key()] = ref.Asset
+			}
+		}
+	}
+	current := State{Collections: map[string]CollectionState{}}
+	collections := c.CollectionsPager(&pexels.CollectionParams{PerPage: 80})
+	for collections.Next(ctx) {
+		col := collections.Item()
+		cs, err := s.collection(ctx, col)
+		if err != nil {
+			return report, err
+		}
+		current.Collections[col.ID] = cs
+	}
+	if err := collections.Err(); err != nil {
+		return report, err
+	}
+
+	diff(&report, old, current)
+	for _, col := range report.RemovedCollections {
+		dir := filepath.Join(cfg.Dir, "collections", url.PathEscape(col.ID))
+		if err := os.RemoveAll(dir); err != nil {
+			return report, fmt.Errorf("mirror: %w", err)
+		}
+	}
+	if cfg.Prune {
+		if err := s.prune(old, current); err != nil {
+			return report, err
+		}
+	}
+
+	report.Finished = time.Now().UTC()
+	current.Synced = report.Finished
+	if err := writeJSON(filepath.Join(cfg.Dir, stateFile), current); err != nil {
+		return report, err
+	}
+	return report, writeJSON(filepath.Join(cfg.Dir, "report.json"), report)
+}
+
+type syncer struct {
+	cfg    Config
+	client *pexels.Client
+	report *Report
+	// assets are the assets of the last Sync by media key, kept for media
+	// still mirrored when this Sync does not download.
+	assets map[string]string
+}
+
+// collection mirrors col along with all of its media.
+func (s *syncer) collection(
+	ctx context.Context, col pexels.Collection,
+) (CollectionState, error) {
+	cs := CollectionState{Collection: col}
+	payload := pexels.MediaPayload{ID: col.ID}
+	media := s.client.CollectionPager(
+		&pexels.CollectionMediaParams{ID: col.ID, PerPage: 80})
+	for media.Next(ctx) {
+		m := media.Item()
+		// The type is what tells photos and videos apart when media.json is
+		// read back, so make sure it is always there.
+		switch m := m.(type) {
+		case *pexels.Photo:
+			m.Type = string(pexels.TypePhoto)
+		case *pexels.Video:
+			m.Type = string(pexels.TypeVideo)
+		}
+		ref := newMediaRef(m)
+		if s.cfg.Assets {
+			asset, err := s.asset(ctx, m)
+			if err != nil {
+				return cs, err
+			}
+			ref.Asset = asset
+		} else {
+			ref.Asset = s.assets[ref.key()]
+		}
+		payload.Media = append(payload.Media, m)
+		cs.Media = append(cs.Media, ref)
+	}
+	if err := media.Err(); err != nil {
+		return cs, err
+	}
+	payload.TotalResults = uint32(len(payload.Media))
+
+	dir := filepath.Join(s.cfg.Dir, "collections", url.PathEscape(col.ID))
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return cs, fmt.Errorf("mirror: %w", err)
+	}
+	if err := writeJSON(filepath.Join(dir, "collection.json"), col); err != nil {
+		return cs, err
+	}
+	return cs, writeJSON(filepath.Join(dir, "media.json"), payload)
+}
+
+// asset downloads m unless it was downloaded before, and returns its path
+// relative to the mirror directory. Media without anything to download have
+// no asset.
+func (s *syncer) asset(ctx context.Context, m pexels.Media) (string, error) {
+	var (
+		name     string
+		download func(f *os.File, opts ...pexels.DownloadOption) error
+	)
+	switch m := m.(type) {
+	case *pexels.Photo:
+		link := m.Src.URL(s.cfg.PhotoVariant)
+		if link == "" {
+			return "", nil
+		}
+		name = path.Join("assets/photos", fmt.Sprint(m.ID)+pexels.FileExt(link))
+		download = func(f *os.File, opts ...pexels.DownloadOption) error {
+			_, err := s.client.DownloadPhoto(ctx, *m, s.cfg.PhotoVariant, f, opts...)
+			return err
+		}
+	case *pexels.Video:
+		file, ok := m.BestFile(s.cfg.VideoFile)
+		if !ok {
+			return "", nil
+		}
+		name = path.Join("assets/videos", fmt.Sprint(m.ID)+pexels.FileExt(file.Link))
+		download = func(f *os.File, opts ...pexels.DownloadOption) error {
+			_, err := s.client.DownloadVideo(ctx, file, f, opts...)
+			return err
+		}
+	default:
+		return "", nil
+	}
+
+	dst := filepath.Join(s.cfg.Dir, filepath.FromSlash(name))
+	if _, err := os.Stat(dst); err == nil {
+		return name, nil
+	}
+	// Download next to the asset and move it in place once complete, an
+	// interrupted download is resumed by the next Sync.
+	part := dst + ".part"
+	f, err := os.OpenFile(part, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return "", fmt.Errorf("mirror: %w", err)
+	}
+	info, err := f.Stat()
+	if err == nil {
+		err = download(f, pexels.ResumeFrom(info.Size()))
+	}
+	if closeErr := f.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("mirror: %w", closeErr)
+	}
+	if err != nil {
+		return "", err
+	}
+	if err := os.Rename(part, dst); err != nil {
+		return "", fmt.Errorf("mirror: %w", err)
+	}
+	s.report.Downloaded++
+	return name, nil
+}
+
+// prune removes the assets of old that are not used by current.
+func (s *syncer) prune(old, current State) error {
+	used := map[string]bool{}
+	for _, cs := range current.Collections {
+		for _, ref := range cs.Media {
+			used[ref.Asset] = true
+		}
+	}
+	for _, cs := range old.Collections {
+		for _, ref := range cs.Media {
+			if ref.Asset == "" || used[ref.Asset] {
+				continue
+			}
+			used[ref.Asset] = true
+			err := os.Remove(filepath.Join(s.cfg.Dir, filepath.FromSlash(ref.Asset)))
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			if err != nil {
+				return fmt.Errorf("mirror: %w", err)
+			}
+			s.report.Pruned++
+		}
+	}
+	return nil
+}
+
+// diff fills in the changes of report between the old and current State.
+func diff(report *Report, old, current State) {
+	for _, id := range sortedIDs(current.Collections, old.Collections) {
+		before, existed := old.Collections[id]
+		after, exists := current.Collections[id]
+		ch := CollectionChange{Collection: after.Collection}
+		switch {
+		case !existed:
+			report.AddedCollections = append(report.AddedCollections, after.Collection)
+			ch.Details = true
+		case !exists:
+			report.RemovedCollections = append(report.RemovedCollections, before.Collection)
+			ch.Collection, ch.Details = before.Collection, true
+		default:
+			b, a := before.Collection, after.Collection
+			ch.Details = b.Title != a.Title || b.Description != a.Description ||
+				b.Private != a.Private
+		}
+		ch.Added = missing(after.Media, before.Media)
+		ch.Removed = missing(before.Media, after.Media)
+		if ch.Details || len(ch.Added) > 0 || len(ch.Removed) > 0 {
+			report.Changed = append(report.Changed, ch)
+		}
+	}
+}
+
+// missing returns the refs of from that are not in in.
+func missing(from, in []MediaRef) []MediaRef {
+	keys := make(map[string]bool, len(in))
+	for _, ref := range in {
+		keys[ref.key()] = true
+	}
+	var refs []MediaRef
+	for _, ref := range from {
+		if !keys[ref.key()] {
+			refs = append(refs, ref)
+		}
+	}
+	return refs
+}
+
+func sortedIDs(sets ...map[string]CollectionState) []string {
+	seen := map[string]bool{}
+	var ids []string
+	for _, set := range sets {
+		for id := range set {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
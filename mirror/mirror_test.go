@@ -0,0 +1,161 @@
+package mirror_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/j-mnr/pexels-go"
+	"github.com/j-mnr/pexels-go/mirror"
+	"github.com/j-mnr/pexels-go/pexelstest"
+	"github.com/matryer/is"
+)
+
+// newServer returns a Server whose collection media link to assets served by
+// cdn.
+func newServer(t *testing.T) *pexelstest.Server {
+	t.Helper()
+	cdn := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			http.ServeContent(w, r, r.URL.Path, time.Time{},
+				strings.NewReader(assetContent(r.URL.Path)))
+		}))
+	t.Cleanup(cdn.Close)
+	srv := pexelstest.NewServer()
+	t.Cleanup(srv.Close)
+	for _, fc := range srv.Collections {
+		for _, m := range fc.Media {
+			switch m := m.(type) {
+			case *pexels.Photo:
+				m.Src.Original = fmt.Sprintf("%s/photos/%d.jpeg", cdn.URL, m.ID)
+			case *pexels.Video:
+				for i := range m.VideoFiles {
+					m.VideoFiles[i].Link = fmt.Sprintf("%s/videos/%d-%d.mp4",
+						cdn.URL, m.ID, i)
+				}
+			}
+		}
+	}
+	return srv
+}
+
+func assetContent(path string) string { return "content of " + path }
+
+func countFiles(t *testing.T, dir string) int {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	is.New(t).NoErr(err)
+	return len(entries)
+}
+
+func TestSyncReportsChanges(t *testing.T) {
+	is := is.New(t)
+	srv := newServer(t)
+	c, err := srv.NewClient()
+	is.NoErr(err)
+	ctx := context.Background()
+	cfg := mirror.Config{Dir: t.TempDir()}
+
+	report, err := mirror.Sync(ctx, c, cfg)
+	is.NoErr(err)
+	is.Equal(len(report.AddedCollections), 3)
+	is.Equal(len(report.Changed), 3)
+	is.Equal(len(report.Changed[0].Added), len(srv.Collections[0].Media))
+
+	report, err = mirror.Sync(ctx, c, cfg)
+	is.NoErr(err)
+	is.True(report.Empty())
+
+	first := srv.Collections[0]
+	removed := first.Media[0]
+	added := &srv.Photos[100]
+	added.Type = string(pexels.TypePhoto)
+	srv.Collections[0].Media = append(first.Media[1:], added)
+	srv.Collections[1].Collection.Title = "Renamed"
+	gone := srv.Collections[2].Collection
+	srv.Collections = srv.Collections[:2]
+
+	report, err = mirror.Sync(ctx, c, cfg)
+	is.NoErr(err)
+	is.Equal(report.AddedCollections, nil)
+	is.Equal(report.RemovedCollections, []pexels.Collection{gone})
+	is.Equal(len(report.Changed), 3)
+
+	is.Equal(report.Changed[0].Collection.ID, first.Collection.ID)
+	is.True(!report.Changed[0].Details)
+	is.Equal(report.Changed[0].Added,
+		[]mirror.MediaRef{{Type: "Photo", ID: fmt.Sprint(added.ID)}})
+	is.Equal(report.Changed[0].Removed,
+		[]mirror.MediaRef{{Type: "Photo", ID: fmt.Sprint(removed.(*pexels.Photo).ID)}})
+
+	is.True(report.Changed[1].Details)
+	is.Equal(len(report.Changed[1].Added)+len(report.Changed[1].Removed), 0)
+
+	is.Equal(len(report.Changed[2].Removed), 7)
+	_, err = os.Stat(filepath.Join(cfg.Dir, "collections", gone.ID))
+	is.True(os.IsNotExist(err))
+}
+
+func TestSyncPruneKeepsAssetsWhenNotDownloading(t *testing.T) {
+	is := is.New(t)
+	srv := newServer(t)
+	c, err := srv.NewClient()
+	is.NoErr(err)
+	ctx := context.Background()
+	dir := t.TempDir()
+	assets := func() int {
+		return countFiles(t, filepath.Join(dir, "assets", "photos")) +
+			countFiles(t, filepath.Join(dir, "assets", "videos"))
+	}
+
+	report, err := mirror.Sync(ctx, c, mirror.Config{Dir: dir, Assets: true})
+	is.NoErr(err)
+	is.Equal(report.Downloaded, 21)
+	is.Equal(assets(), 21)
+
+	// Nothing changed, so nothing may be pruned even though this Sync does
+	// not download.
+	report, err = mirror.Sync(ctx, c, mirror.Config{Dir: dir, Prune: true})
+	is.NoErr(err)
+	is.Equal(report.Pruned, 0)
+	is.Equal(assets(), 21)
+	st, err := mirror.LoadState(dir)
+	is.NoErr(err)
+	is.True(st.Collections["col0001"].Media[0].Asset != "")
+
+	srv.Collections = srv.Collections[:2]
+	report, err = mirror.Sync(ctx, c, mirror.Config{Dir: dir, Prune: true})
+	is.NoErr(err)
+	is.Equal(report.Pruned, 7)
+	is.Equal(assets(), 14)
+}
+
+func TestSyncFinishesCompletedPart(t *testing.T) {
+	is := is.New(t)
+	srv := newServer(t)
+	srv.Collections = srv.Collections[:1]
+	photo := srv.Collections[0].Media[0].(*pexels.Photo)
+	c, err := srv.NewClient()
+	is.NoErr(err)
+	dir := t.TempDir()
+
+	// A download that completed before the Sync stopped, but was never moved
+	// in place.
+	name := filepath.Join(dir, "assets", "photos", fmt.Sprint(photo.ID)+".jpeg")
+	is.NoErr(os.MkdirAll(filepath.Dir(name), 0o755))
+	content := assetContent(fmt.Sprintf("/photos/%d.jpeg", photo.ID))
+	is.NoErr(os.WriteFile(name+".part", []byte(content), 0o644))
+
+	_, err = mirror.Sync(context.Background(), c,
+		mirror.Config{Dir: dir, Assets: true})
+	is.NoErr(err)
+	data, err := os.ReadFile(name)
+	is.NoErr(err)
+	is.Equal(string(data), content)
+}
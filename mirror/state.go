@@ -0,0 +1,88 @@
+package mirror
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/j-mnr/pexels-go"
+	"github.com/j-mnr/pexels-go/internal/atomicfile"
+)
+
+// stateFile is the name of the State within the mirror directory.
+const stateFile = "state.json"
+
+// State is what the mirror held after the last successful Sync. It is what
+// the next Sync compares against to find the changes.
+type State struct {
+	Synced      time.Time                  `json:"synced"`
+	Collections map[string]CollectionState `json:"collections"`
+}
+
+// CollectionState is a mirrored collection and its media, in order.
+type CollectionState struct {
+	Collection pexels.Collection `json:"collection"`
+	Media      []MediaRef        `json:"media"`
+}
+
+// MediaRef identifies a Media within a collection.
+type MediaRef struct {
+	Type string `json:"type"` // "Photo", "Video" or any type Pexels adds
+	ID   string `json:"id"`
+	// Asset is the path of the downloaded file relative to the mirror
+	// directory, empty if it was not downloaded.
+	Asset string `json:"asset,omitempty"`
+}
+
+func (r MediaRef) key() string { return r.Type + ":" + r.ID }
+
+// LoadState reads the State of the mirror in dir. A directory that was never
+// synced has an empty State.
+func LoadState(dir string) (State, error) {
+	st := State{Collections: map[string]CollectionState{}}
+	data, err := os.ReadFile(filepath.Join(dir, stateFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return st, nil
+	}
+	if err != nil {
+		return st, fmt.Errorf("mirror: %w", err)
+	}
+	if err := json.Unmarshal(data, &st); err != nil {
+		return st, fmt.Errorf("mirror: reading state: %w", err)
+	}
+	if st.Collections == nil {
+		st.Collections = map[string]CollectionState{}
+	}
+	return st, nil
+}
+
+// newMediaRef returns the MediaRef of m.
+func newMediaRef(m pexels.Media) MediaRef {
+	switch m := m.(type) {
+	case *pexels.Photo:
+		return MediaRef{Type: string(pexels.TypePhoto), ID: strconv.FormatUint(m.ID, 10)}
+	case *pexels.Video:
+		return MediaRef{Type: string(pexels.TypeVideo), ID: strconv.FormatUint(m.ID, 10)}
+	case *pexels.UnknownMedia:
+		return MediaRef{Type: m.Type, ID: m.ID()}
+	}
+	return MediaRef{}
+}
+
+// writeJSON writes v to path through a temporary file, so a crash never
+// leaves half of a file behind.
+func writeJSON(path string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("mirror: %w", err)
+	}
+	if err := atomicfile.WriteFile(path, append(data, '\n')); err != nil {
+		return fmt.Errorf("mirror: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package pexels
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	ErrNoPage     = errors.New("there is no page to go to")
+	ErrForeignURL = errors.New("the URL does not point at the Pexels API")
+)
+
+// Payload is any of the paginated payloads that can be fetched by FetchPage.
+type Payload interface {
+	PhotoPayload | VideoPayload | MediaPayload | CollectionPayload
+}
+
+// FetchPage fetches the page pageURL points to, usually Pagination.NextPage or
+// Pagination.PrevPage, and decodes it into the Payload T. The URL must live
+// under the Client's RootPhotoURL or RootVideoURL, otherwise ErrForeignURL is
+// returned and no request is made.
+func FetchPage[T Payload](
+	ctx context.Context, c *Client, pageURL string,
+) (T, ResponseCommon, error) {
+	var payload T
+	if pageURL == "" {
+		return payload, ResponseCommon{}, ErrNoPage
+	}
+	resp, err := getURL(ctx, *c, pageURL, &payload)
+	if err != nil {
+		return payload, ResponseCommon{}, err
+	}
+	return payload, resp.Common, nil
+}
+
+// NextPhotos returns the page of photos after pr. ErrNoPage is returned if pr
+// is the last page.
+func (c *Client) NextPhotos(
+	ctx context.Context, pr PhotosResponse,
+) (PhotosResponse, error) {
+	return c.photosPage(ctx, pr.Payload.NextPage)
+}
+
+// PrevPhotos returns the page of photos before pr. ErrNoPage is returned if pr
+// is the first page.
+func (c *Client) PrevPhotos(
+	ctx context.Context, pr PhotosResponse,
+) (PhotosResponse, error) {
+	return c.photosPage(ctx, pr.Payload.PrevPage)
+}
+
+func (c *Client) photosPage(
+	ctx context.Context, pageURL string,
+) (PhotosResponse, error) {
+	payload, common, err := FetchPage[PhotoPayload](ctx, c, pageURL)
+	if err != nil {
+		return PhotosResponse{}, err
+	}
+	return PhotosResponse{Common: common, Payload: payload}, nil
+}
+
+// NextVideos returns the page of videos after vr. ErrNoPage is returned if vr
+// is the last page.
+func (c *Client) NextVideos(
+	ctx context.Context, vr VideosResponse,
+) (VideosResponse, error) {
+	return c.videosPage(ctx, vr.Payload.NextPage)
+}
+
+// PrevVideos returns the page of videos before vr. ErrNoPage is returned if vr
+// is the first page.
+func (c *Client) PrevVideos(
+	ctx context.Context, vr VideosResponse,
+) (VideosResponse, error) {
+	return c.videosPage(ctx, vr.Payload.PrevPage)
+}
+
+func (c *Client) videosPage(
+	ctx context.Context, pageURL string,
+) (VideosResponse, error) {
+	payload, common, err := FetchPage[VideoPayload](ctx, c, pageURL)
+	if err != nil {
+		return VideosResponse{}, err
+	}
+	return VideosResponse{Common: common, Payload: payload}, nil
+}
+
+// NextCollection returns the page of media after mr within the same
+// collection. ErrNoPage is returned if mr is the last page.
+func (c *Client) NextCollection(
+	ctx context.Context, mr MediaResponse,
+) (MediaResponse, error) {
+	return c.collectionPage(ctx, mr.NextPage)
+}
+
+// PrevCollection returns the page of media before mr within the same
+// collection. ErrNoPage is returned if mr is the first page.
+func (c *Client) PrevCollection(
+	ctx context.Context, mr MediaResponse,
+) (MediaResponse, error) {
+	return c.collectionPage(ctx, mr.PrevPage)
+}
+
+func (c *Client) collectionPage(
+	ctx context.Context, pageURL string,
+) (MediaResponse, error) {
+	if pageURL == "" {
+		return MediaResponse{}, ErrNoPage
+	}
+	resp, err := getURL(ctx, *c, pageURL, &MediaPayload{})
+	if err != nil {
+		return MediaResponse{}, err
+	}
+	return newMediaResponse(resp), nil
+}
+
+// NextCollections returns the page of collections after cr. ErrNoPage is
+// returned if cr is the last page.
+func (c *Client) NextCollections(
+	ctx context.Context, cr CollectionsResponse,
+) (CollectionsResponse, error) {
+	return c.collectionsPage(ctx, cr.Payload.NextPage)
+}
+
+// PrevCollections returns the page of collections before cr. ErrNoPage is
+// returned if cr is the first page.
+func (c *Client) PrevCollections(
+	ctx context.Context, cr CollectionsResponse,
+) (CollectionsResponse, error) {
+	return c.collectionsPage(ctx, cr.Payload.PrevPage)
+}
+
+func (c *Client) collectionsPage(
+	ctx context.Context, pageURL string,
+) (CollectionsResponse, error) {
+	payload, common, err := FetchPage[CollectionPayload](ctx, c, pageURL)
+	if err != nil {
+		return CollectionsResponse{}, err
+	}
+	return CollectionsResponse{Common: common, Payload: payload}, nil
+}
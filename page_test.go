@@ -0,0 +1,156 @@
+package pexels_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/j-mnr/pexels-go"
+	"github.com/j-mnr/pexels-go/pexelstest"
+	"github.com/matryer/is"
+)
+
+// countingClient answers every request with an empty page and counts them.
+type countingClient struct {
+	requests []*http.Request
+}
+
+func (c *countingClient) Do(req *http.Request) (*http.Response, error) {
+	c.requests = append(c.requests, req)
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Status:     "200 OK",
+		Header:     http.Header{},
+		Body:       io.NopCloser(strings.NewReader("{}")),
+		Request:    req,
+	}, nil
+}
+
+func TestFetchPageOnlyFollowsPexelsURLs(t *testing.T) {
+	tests := map[string]struct {
+		url     string
+		wantErr error
+	}{
+		"photo page":   {url: "https://api.pexels.com/v1/curated/?page=2"},
+		"video page":   {url: "https://api.pexels.com/videos/popular/?page=2"},
+		"empty":        {url: "", wantErr: pexels.ErrNoPage},
+		"foreign host": {url: "https://evil.example/v1/curated/?page=2", wantErr: pexels.ErrForeignURL},
+		"subdomain":    {url: "https://api.pexels.com.evil.example/v1/curated/", wantErr: pexels.ErrForeignURL},
+		"user info":    {url: "https://api.pexels.com@evil.example/v1/curated/", wantErr: pexels.ErrForeignURL},
+		"other scheme": {url: "http://api.pexels.com/v1/curated/?page=2", wantErr: pexels.ErrForeignURL},
+		"lookalike":    {url: "https://api.pexels.com/v1evil/curated/", wantErr: pexels.ErrForeignURL},
+		"relative":     {url: "/v1/curated/?page=2", wantErr: pexels.ErrForeignURL},
+	}
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			is := is.New(t)
+			hc := &countingClient{}
+			c, err := pexels.New("key", pexels.WithHTTPClient(hc))
+			is.NoErr(err)
+
+			_, _, err = pexels.FetchPage[pexels.PhotoPayload](
+				context.Background(), c, tc.url)
+			if tc.wantErr != nil {
+				is.True(errors.Is(err, tc.wantErr))
+				is.Equal(len(hc.requests), 0)
+				return
+			}
+			is.NoErr(err)
+			is.Equal(len(hc.requests), 1)
+			is.Equal(hc.requests[0].Header.Get("Authorization"), "key")
+		})
+	}
+}
+
+func TestNoPageBeyondEnds(t *testing.T) {
+	srv := pexelstest.NewServer()
+	defer srv.Close()
+	c, err := srv.NewClient()
+	is.New(t).NoErr(err)
+	ctx := context.Background()
+	colID := srv.Collections[0].Collection.ID
+
+	tests := map[string]func() error{
+		"first photos": func() error {
+			pr, err := c.GetCuratedPhotos(ctx, nil)
+			if err != nil {
+				return err
+			}
+			_, err = c.PrevPhotos(ctx, pr)
+			return err
+		},
+		"last photos": func() error {
+			pr, err := c.GetCuratedPhotos(ctx,
+				&pexels.CuratedPhotosParams{Page: 2, PerPage: 60})
+			if err != nil {
+				return err
+			}
+			_, err = c.NextPhotos(ctx, pr)
+			return err
+		},
+		"first videos": func() error {
+			vr, err := c.GetPopularVideos(ctx, nil)
+			if err != nil {
+				return err
+			}
+			_, err = c.PrevVideos(ctx, vr)
+			return err
+		},
+		"last videos": func() error {
+			vr, err := c.GetPopularVideos(ctx,
+				&pexels.PopularVideoParams{PerPage: 80})
+			if err != nil {
+				return err
+			}
+			_, err = c.NextVideos(ctx, vr)
+			return err
+		},
+		"first collection media": func() error {
+			mr, err := c.GetCollection(ctx,
+				&pexels.CollectionMediaParams{ID: colID, PerPage: 3})
+			if err != nil {
+				return err
+			}
+			_, err = c.PrevCollection(ctx, mr)
+			return err
+		},
+		"last collection media": func() error {
+			mr, err := c.GetCollection(ctx,
+				&pexels.CollectionMediaParams{ID: colID, Page: 3, PerPage: 3})
+			if err != nil {
+				return err
+			}
+			_, err = c.NextCollection(ctx, mr)
+			return err
+		},
+		"first collections": func() error {
+			cr, err := c.GetCollections(ctx, nil)
+			if err != nil {
+				return err
+			}
+			_, err = c.PrevCollections(ctx, cr)
+			return err
+		},
+		"last collections": func() error {
+			cr, err := c.GetCollections(ctx, nil)
+			if err != nil {
+				return err
+			}
+			_, err = c.NextCollections(ctx, cr)
+			return err
+		},
+	}
+	for name, fn := range tests {
+		fn := fn
+		t.Run(name, func(t *testing.T) {
+			is := is.New(t)
+			requests := srv.Requests()
+			is.True(errors.Is(fn(), pexels.ErrNoPage))
+			is.Equal(srv.Requests(), requests+1)
+		})
+	}
+}
@@ -0,0 +1,264 @@
+package pexels
+
+import (
+	"context"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// Pager walks through every page of a paginated endpoint one item at a time.
+// It follows the pages until Pexels reports there is no next page, all of the
+// TotalResults were seen, the Limit is reached or the context is done.
+//
+//	p := client.SearchPhotosPager(&pexels.PhotoSearchParams{Query: "ocean"})
+//	for p.Next(ctx) {
+//		photo := p.Item()
+//		// ...
+//	}
+//	if err := p.Err(); err != nil {
+//		// ...
+//	}
+//
+// The position of a Pager can be saved at any time with Cursor and picked up
+// again later, even by another process, with ResumePager.
+type Pager[T any] struct {
+	fetch func(ctx context.Context, page uint16) ([]T, Pagination, error)
+	key   func(T) string
+
+	cursor  Cursor
+	page    uint16
+	current uint16
+	// pageIDs are the keys of the items of the current page yielded so far.
+	pageIDs []string
+	// skip holds the keys of the items to leave out of the first page after
+	// resuming from a Cursor.
+	skip  map[string]bool
+	items []T
+	item  T
+	seen  int
+	limit int
+	more  bool
+	err   error
+	last  Pagination
+}
+
+// newPager returns a Pager fetching the pages of the endpoint and query of
+// cur, starting at cur.Page. If err is not nil the Pager stops straight away
+// with it, which is used for params that did not pass Validate.
+func newPager[P any, T any](
+	c *Client, cur Cursor, err error,
+	items func(*P) ([]T, Pagination), key func(T) string,
+) *Pager[T] {
+	if cur.Page == 0 {
+		cur.Page = 1
+	}
+	if cur.Started.IsZero() {
+		cur.Started = time.Now().UTC()
+	}
+	p := &Pager[T]{
+		key:    key,
+		cursor: cur,
+		page:   cur.Page,
+		more:   !cur.Done,
+		err:    err,
+	}
+	if len(cur.SeenIDs) > 0 {
+		p.pageIDs = slices.Clone(cur.SeenIDs)
+		p.skip = make(map[string]bool, len(cur.SeenIDs))
+		for _, id := range cur.SeenIDs {
+			p.skip[id] = true
+		}
+	}
+	p.fetch = func(ctx context.Context, page uint16) ([]T, Pagination, error) {
+		query, err := url.ParseQuery(cur.Query)
+		if err != nil {
+			return nil, Pagination{}, err
+		}
+		query.Set("page", strconv.FormatUint(uint64(page), 10))
+		resp, err := get(ctx, *c, cur.Endpoint, query, new(P))
+		if err != nil {
+			return nil, Pagination{}, err
+		}
+		ts, pg := items(resp.Data)
+		return ts, pg, nil
+	}
+	return p
+}
+
+// Limit caps the number of items the Pager yields. Zero or less means no cap.
+func (p *Pager[T]) Limit(n int) *Pager[T] {
+	p.limit = n
+	return p
+}
+
+// Next advances the Pager to the next item, fetching the next page when the
+// current one is used up. It returns false when there are no more items or an
+// error occurred, which is then available through Err.
+func (p *Pager[T]) Next(ctx context.Context) bool {
+	if p.err != nil || (p.limit > 0 && p.seen >= p.limit) {
+		return false
+	}
+	if err := ctx.Err(); err != nil {
+		p.err = err
+		return false
+	}
+	for len(p.items) == 0 {
+		if !p.more {
+			return false
+		}
+		items, pg, err := p.fetch(ctx, p.page)
+		if err != nil {
+			p.err = err
+			return false
+		}
+		more := len(items) > 0 && pg.NextPage != "" &&
+			(pg.PerPage == 0 || uint32(pg.Page)*uint32(pg.PerPage) < pg.TotalResults)
+		if p.skip != nil {
+			items = slices.DeleteFunc(items, func(t T) bool {
+				return p.skip[p.key(t)]
+			})
+			p.skip = nil
+		} else {
+			p.pageIDs = nil
+		}
+		p.items = items
+		p.last = pg
+		p.current = p.page
+		p.page++
+		p.more = more
+	}
+	p.item, p.items = p.items[0], p.items[1:]
+	p.pageIDs = append(p.pageIDs, p.key(p.item))
+	p.seen++
+	return true
+}
+
+// Item returns the current item, it is only valid after Next returned true.
+func (p *Pager[T]) Item() T { return p.item }
+
+// Err returns the error that stopped the Pager, if any. When the context
+// passed to Next is done its error is returned.
+func (p *Pager[T]) Err() error { return p.err }
+
+// Pagination returns the pagination details of the last page fetched.
+func (p *Pager[T]) Pagination() Pagination { return p.last }
+
+// Cursor returns the position of the Pager: resuming from it yields the items
+// that were not yielded yet. It does not hold the Limit.
+func (p *Pager[T]) Cursor() Cursor {
+	cur := p.cursor
+	cur.Updated = time.Now().UTC()
+	if len(p.items) == 0 && p.skip == nil {
+		cur.Page, cur.SeenIDs, cur.Done = p.page, nil, !p.more
+		return cur
+	}
+	cur.Page, cur.SeenIDs, cur.Done = p.current, slices.Clone(p.pageIDs), false
+	if p.skip != nil {
+		cur.Page = p.page
+	}
+	return cur
+}
+
+// SearchPhotosPager returns a Pager over every Photo matching psp, starting at
+// psp.Page.
+func (c *Client) SearchPhotosPager(psp *PhotoSearchParams) *Pager[Photo] {
+	var params PhotoSearchParams
+	if psp != nil {
+		params = *psp
+	}
+	return newPager(c, newCursor(searchPhotosEndpoint, &params),
+		params.Validate(), photoItems, photoKey)
+}
+
+// CuratedPhotosPager returns a Pager over the Curated list, starting at
+// cpp.Page.
+func (c *Client) CuratedPhotosPager(cpp *CuratedPhotosParams) *Pager[Photo] {
+	var params CuratedPhotosParams
+	if cpp != nil {
+		params = *cpp
+	}
+	return newPager(c, newCursor(curatedPhotosEndpoint, &params),
+		params.Validate(), photoItems, photoKey)
+}
+
+// SearchVideosPager returns a Pager over every Video matching vsp, starting at
+// vsp.Page.
+func (c *Client) SearchVideosPager(vsp *VideoSearchParams) *Pager[Video] {
+	var params VideoSearchParams
+	if vsp != nil {
+		params = *vsp
+	}
+	return newPager(c, newCursor(searchVideosEndpoint, &params),
+		params.Validate(), videoItems, videoKey)
+}
+
+// PopularVideosPager returns a Pager over the popular videos, starting at
+// pvp.Page.
+func (c *Client) PopularVideosPager(pvp *PopularVideoParams) *Pager[Video] {
+	var params PopularVideoParams
+	if pvp != nil {
+		params = *pvp
+	}
+	return newPager(c, newCursor(popularVideosEndpoint, &params),
+		params.Validate(), videoItems, videoKey)
+}
+
+// CollectionPager returns a Pager over all the Media of a single collection in
+// the order they appear in it, starting at params.Page.
+func (c *Client) CollectionPager(params *CollectionMediaParams) *Pager[Media] {
+	var query CollectionMediaParams
+	if params != nil {
+		query = *params
+	}
+	return newPager(c, newCursor("/collections/"+query.ID, &query),
+		query.Validate(), mediaItems, mediaKey)
+}
+
+// CollectionsPager returns a Pager over all of your collections, starting at
+// params.Page.
+func (c *Client) CollectionsPager(params *CollectionParams) *Pager[Collection] {
+	var query CollectionParams
+	if params != nil {
+		query = *params
+	}
+	return newPager(c, newCursor("/collections", &query),
+		query.Validate(), collectionItems, collectionKey)
+}
+
+func photoItems(p *PhotoPayload) ([]Photo, Pagination) {
+	return p.Photos, p.Pagination
+}
+
+func videoItems(p *VideoPayload) ([]Video, Pagination) {
+	return p.Videos, p.Pagination
+}
+
+func mediaItems(p *MediaPayload) ([]Media, Pagination) {
+	return p.Media, p.Pagination
+}
+
+func collectionItems(p *CollectionPayload) ([]Collection, Pagination) {
+	return p.Collections, p.Pagination
+}
+
+func photoKey(p Photo) string { return strconv.FormatUint(p.ID, 10) }
+
+func videoKey(v Video) string { return strconv.FormatUint(v.ID, 10) }
+
+func collectionKey(c Collection) string { return c.ID }
+
+// mediaKey identifies m within a collection, where photos and videos may share
+// an ID.
+func mediaKey(m Media) string {
+	switch m := m.(type) {
+	case *Photo:
+		return string(TypePhoto) + ":" + photoKey(*m)
+	case *Video:
+		return string(TypeVideo) + ":" + videoKey(*m)
+	case *UnknownMedia:
+		return m.Type + ":" + m.ID()
+	}
+	return ""
+}
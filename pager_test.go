@@ -0,0 +1,194 @@
+package pexels_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/j-mnr/pexels-go"
+	"github.com/j-mnr/pexels-go/pexelstest"
+	"github.com/matryer/is"
+)
+
+func mediaIDs(ms []pexels.Media) []string {
+	ids := make([]string, len(ms))
+	for i, m := range ms {
+		switch m := m.(type) {
+		case *pexels.Photo:
+			ids[i] = fmt.Sprint("photo ", m.ID)
+		case *pexels.Video:
+			ids[i] = fmt.Sprint("video ", m.ID)
+		}
+	}
+	return ids
+}
+
+func drain[T any](t *testing.T, p *pexels.Pager[T]) []T {
+	t.Helper()
+	var items []T
+	for p.Next(context.Background()) {
+		items = append(items, p.Item())
+	}
+	is.New(t).NoErr(p.Err())
+	return items
+}
+
+// roundTrip stores cur the way a job would and reads it back.
+func roundTrip(t *testing.T, cur pexels.Cursor) pexels.Cursor {
+	t.Helper()
+	is := is.New(t)
+	data, err := json.Marshal(cur)
+	is.NoErr(err)
+	var got pexels.Cursor
+	is.NoErr(json.Unmarshal(data, &got))
+	return got
+}
+
+func TestPagerFollowsPages(t *testing.T) {
+	is := is.New(t)
+	srv := pexelstest.NewServer()
+	defer srv.Close()
+	c, err := srv.NewClient()
+	is.NoErr(err)
+
+	photos := drain(t, c.CuratedPhotosPager(&pexels.CuratedPhotosParams{PerPage: 50}))
+	is.Equal(len(photos), len(srv.Photos))
+	is.Equal(srv.Requests(), 3)
+
+	limited := drain(t, c.CuratedPhotosPager(
+		&pexels.CuratedPhotosParams{Page: 2, PerPage: 10}).Limit(15))
+	is.Equal(len(limited), 15)
+	is.Equal(limited[0].ID, srv.Photos[10].ID)
+
+	p := c.SearchPhotosPager(&pexels.PhotoSearchParams{})
+	is.True(!p.Next(context.Background()))
+	is.True(errors.Is(p.Err(), pexels.ErrInvalidParams))
+}
+
+func TestResumePagerAtEveryPosition(t *testing.T) {
+	srv := pexelstest.NewServer()
+	defer srv.Close()
+	c, err := srv.NewClient()
+	is.New(t).NoErr(err)
+	params := &pexels.CollectionMediaParams{
+		ID: srv.Collections[0].Collection.ID, PerPage: 3,
+	}
+	want := mediaIDs(drain(t, c.CollectionPager(params)))
+	is.New(t).Equal(len(want), 7)
+
+	for stop := 0; stop <= len(want); stop++ {
+		t.Run(fmt.Sprint("after ", stop), func(t *testing.T) {
+			is := is.New(t)
+			p := c.CollectionPager(params)
+			var got []pexels.Media
+			for len(got) < stop && p.Next(context.Background()) {
+				got = append(got, p.Item())
+			}
+			cur := roundTrip(t, p.Cursor())
+			switch {
+			case stop == len(want):
+				is.True(cur.Done)
+			case stop%3 == 0:
+				// At a page boundary nothing of the next page was seen.
+				is.Equal(cur.SeenIDs, nil)
+				is.Equal(cur.Page, uint16(stop/3+1))
+			default:
+				is.Equal(len(cur.SeenIDs), stop%3)
+				is.Equal(cur.Page, uint16(stop/3+1))
+			}
+
+			resumed, err := pexels.ResumePager[pexels.Media](c, cur)
+			is.NoErr(err)
+			got = append(got, drain(t, resumed)...)
+			is.Equal(mediaIDs(got), want)
+			is.True(resumed.Cursor().Done)
+		})
+	}
+}
+
+func TestResumePagerSkipsWholePage(t *testing.T) {
+	is := is.New(t)
+	srv := pexelstest.NewServer()
+	defer srv.Close()
+	c, err := srv.NewClient()
+	is.NoErr(err)
+	params := &pexels.CuratedPhotosParams{PerPage: 4}
+	all := drain(t, c.CuratedPhotosPager(params))
+
+	// A cursor taken by another process after it yielded all of page 2,
+	// but before it moved on.
+	cur := c.CuratedPhotosPager(params).Cursor()
+	cur.Page = 2
+	for _, p := range all[4:8] {
+		cur.SeenIDs = append(cur.SeenIDs, fmt.Sprint(p.ID))
+	}
+	resumed, err := pexels.ResumePager[pexels.Photo](c, cur)
+	is.NoErr(err)
+	is.Equal(resumed.Cursor().Page, cur.Page)
+	is.Equal(resumed.Cursor().SeenIDs, cur.SeenIDs)
+
+	is.True(resumed.Next(context.Background()))
+	is.Equal(resumed.Item().ID, all[8].ID)
+	rest := append([]pexels.Photo{resumed.Item()}, drain(t, resumed)...)
+	is.Equal(rest, all[8:])
+}
+
+func TestResumePagerDone(t *testing.T) {
+	is := is.New(t)
+	srv := pexelstest.NewServer()
+	defer srv.Close()
+	c, err := srv.NewClient()
+	is.NoErr(err)
+
+	p := c.CollectionsPager(nil)
+	drain(t, p)
+	cur := roundTrip(t, p.Cursor())
+	is.True(cur.Done)
+
+	requests := srv.Requests()
+	resumed, err := pexels.ResumePager[pexels.Collection](c, cur)
+	is.NoErr(err)
+	is.True(!resumed.Next(context.Background()))
+	is.NoErr(resumed.Err())
+	is.Equal(srv.Requests(), requests)
+}
+
+func TestResumePagerMismatch(t *testing.T) {
+	is := is.New(t)
+	srv := pexelstest.NewServer()
+	defer srv.Close()
+	c, err := srv.NewClient()
+	is.NoErr(err)
+
+	cur := c.CollectionsPager(nil).Cursor()
+	_, err = pexels.ResumePager[pexels.Photo](c, cur)
+	is.True(errors.Is(err, pexels.ErrCursorMismatch))
+}
+
+func TestFileCheckpointStore(t *testing.T) {
+	is := is.New(t)
+	store, err := pexels.NewFileCheckpointStore(t.TempDir())
+	is.NoErr(err)
+
+	_, ok, err := store.Load("jobs/ocean")
+	is.NoErr(err)
+	is.True(!ok)
+
+	want := pexels.Cursor{
+		Endpoint: "/search", Query: "query=ocean", Page: 3,
+		SeenIDs: []string{"1", "2"},
+	}
+	is.NoErr(store.Save("jobs/ocean", want))
+	got, ok, err := store.Load("jobs/ocean")
+	is.NoErr(err)
+	is.True(ok)
+	is.Equal(got, want)
+
+	is.NoErr(store.Delete("jobs/ocean"))
+	is.NoErr(store.Delete("jobs/ocean"))
+	_, ok, err = store.Load("jobs/ocean")
+	is.NoErr(err)
+	is.True(!ok)
+}
@@ -0,0 +1,205 @@
+package pexelstest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/j-mnr/pexels-go"
+)
+
+var ErrNoInteraction = errors.New("no recorded interaction matches the request")
+
+// Cassette is a list of recorded HTTP exchanges, stored on disk as JSON so it
+// can be committed along with the tests that replay it.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a single recorded request and the response it received.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is a request of an Interaction. It never holds the
+// Authorization header.
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+}
+
+// RecordedResponse is a response of an Interaction.
+type RecordedResponse struct {
+	StatusCode int         `json:"status_code"` //nolint:tagliatelle
+	Status     string      `json:"status"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body"`
+}
+
+// LoadCassette reads the Cassette stored at path.
+func LoadCassette(path string) (*Cassette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("pexelstest: %w", err)
+	}
+	var c Cassette
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("pexelstest: %w", err)
+	}
+	return &c, nil
+}
+
+// Save writes the Cassette to path.
+func (c *Cassette) Save(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("pexelstest: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("pexelstest: %w", err)
+	}
+	return nil
+}
+
+// Recorder is a pexels.HTTPClient that sends every request through the
+// wrapped HTTPClient and records the exchange. Call Save once done to write
+// the recording to disk.
+type Recorder struct {
+	client pexels.HTTPClient
+	path   string
+
+	mu       sync.Mutex
+	cassette Cassette
+}
+
+// NewRecorder returns a Recorder wrapping client which Saves to path.
+func NewRecorder(client pexels.HTTPClient, path string) *Recorder {
+	return &Recorder{client: client, path: path}
+}
+
+// Do sends req and records its response.
+func (r *Recorder) Do(req *http.Request) (*http.Response, error) {
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	header := req.Header.Clone()
+	header.Del("Authorization")
+	if len(header) == 0 {
+		header = nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request: RecordedRequest{
+			Method: req.Method,
+			URL:    req.URL.String(),
+			Header: header,
+		},
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Status:     resp.Status,
+			Header:     resp.Header.Clone(),
+			Body:       string(body),
+		},
+	})
+	return resp, nil
+}
+
+// Save writes everything recorded so far to the Recorder's path.
+func (r *Recorder) Save() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.cassette.Save(r.path)
+}
+
+// Replayer is a pexels.HTTPClient that answers requests from a Cassette
+// without any network access. Requests are matched on their method, path and
+// query, regardless of the order of the query params or the host. When the
+// same request was recorded more than once the responses are given back in
+// the order they were recorded, repeating the last one afterwards.
+type Replayer struct {
+	mu     sync.Mutex
+	byKey  map[string][]RecordedResponse
+	served map[string]int
+}
+
+// NewReplayer returns a Replayer for the Cassette stored at path.
+func NewReplayer(path string) (*Replayer, error) {
+	c, err := LoadCassette(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewCassetteReplayer(c)
+}
+
+// NewCassetteReplayer returns a Replayer for c.
+func NewCassetteReplayer(c *Cassette) (*Replayer, error) {
+	r := &Replayer{
+		byKey:  map[string][]RecordedResponse{},
+		served: map[string]int{},
+	}
+	for _, in := range c.Interactions {
+		u, err := url.Parse(in.Request.URL)
+		if err != nil {
+			return nil, fmt.Errorf("pexelstest: %w", err)
+		}
+		key := matchKey(in.Request.Method, u)
+		r.byKey[key] = append(r.byKey[key], in.Response)
+	}
+	return r, nil
+}
+
+// Do returns the recorded response matching req, or ErrNoInteraction.
+func (r *Replayer) Do(req *http.Request) (*http.Response, error) {
+	key := matchKey(req.Method, req.URL)
+	r.mu.Lock()
+	responses := r.byKey[key]
+	if len(responses) == 0 {
+		r.mu.Unlock()
+		return nil, fmt.Errorf("%w: %s", ErrNoInteraction, key)
+	}
+	i := min(r.served[key], len(responses)-1)
+	r.served[key]++
+	rec := responses[i]
+	r.mu.Unlock()
+
+	return &http.Response{
+		StatusCode:    rec.StatusCode,
+		Status:        rec.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        rec.Header.Clone(),
+		Body:          io.NopCloser(strings.NewReader(rec.Body)),
+		ContentLength: int64(len(rec.Body)),
+		Request:       req,
+	}, nil
+}
+
+// matchKey identifies a request by method, path and query with its params
+// sorted.
+func matchKey(method string, u *url.URL) string {
+	path := strings.TrimSuffix(u.Path, "/")
+	query := u.Query().Encode()
+	if query == "" {
+		return method + " " + path
+	}
+	return method + " " + path + "?" + query
+}
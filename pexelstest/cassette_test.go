@@ -0,0 +1,68 @@
+package pexelstest_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/j-mnr/pexels-go"
+	"github.com/j-mnr/pexels-go/pexelstest"
+	"github.com/matryer/is"
+)
+
+func TestRecorderReplayer(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	srv := pexelstest.NewServer()
+	retry := pexels.WithRetry(pexels.RetryPolicy{
+		MaxAttempts: 2, BaseBackoff: time.Millisecond,
+	})
+
+	rec := pexelstest.NewRecorder(srv.Client(), path)
+	c, err := srv.NewClient(pexels.WithHTTPClient(rec), retry)
+	is.NoErr(err)
+	search := &pexels.PhotoSearchParams{Query: "ocean", PerPage: 8}
+	first, err := c.SearchPhotos(ctx, search)
+	is.NoErr(err)
+	second, err := c.NextPhotos(ctx, first)
+	is.NoErr(err)
+	srv.FailNext(1, http.StatusServiceUnavailable)
+	video, err := c.GetVideo(ctx, srv.Videos[0].ID)
+	is.NoErr(err)
+	is.NoErr(rec.Save())
+	srv.Close()
+
+	data, err := os.ReadFile(path)
+	is.NoErr(err)
+	is.True(!strings.Contains(string(data), pexelstest.APIKey))
+	cassette, err := pexelstest.LoadCassette(path)
+	is.NoErr(err)
+	is.Equal(len(cassette.Interactions), 4)
+	for _, in := range cassette.Interactions {
+		is.Equal(in.Request.Header.Get("Authorization"), "")
+	}
+
+	rep, err := pexelstest.NewReplayer(path)
+	is.NoErr(err)
+	c, err = srv.NewClient(pexels.WithHTTPClient(rep), retry)
+	is.NoErr(err)
+
+	got, err := c.SearchPhotos(ctx, search)
+	is.NoErr(err)
+	is.Equal(got.Payload, first.Payload)
+	got, err = c.NextPhotos(ctx, got)
+	is.NoErr(err)
+	is.Equal(got.Payload, second.Payload)
+	gotVideo, err := c.GetVideo(ctx, srv.Videos[0].ID)
+	is.NoErr(err)
+	is.Equal(gotVideo.Video, video.Video)
+
+	_, err = c.GetPhoto(ctx, srv.Photos[0].ID)
+	is.True(errors.Is(err, pexelstest.ErrNoInteraction))
+}
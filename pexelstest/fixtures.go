@@ -0,0 +1,178 @@
+package pexelstest
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/j-mnr/pexels-go"
+)
+
+// FixtureCollection is a collection served by the Server along with all of
+// the Media in it, in order.
+type FixtureCollection struct {
+	Collection pexels.Collection
+	Media      []pexels.Media
+}
+
+var (
+	topics = []string{
+		"ocean waves", "pine forest", "city lights", "mountain lake",
+		"desert dunes", "autumn leaves",
+	}
+	creators = []pexels.PexelUser{
+		{ID: 424445, Name: "Johannes Plenio", URL: "https://www.pexels.com/@jplenio"},
+		{ID: 257470, Name: "Rifqi Ramadhan", URL: "https://www.pexels.com/@rifkyilhamrd"},
+		{ID: 1235333, Name: "David Frampton", URL: "https://www.pexels.com/@david-frampton-1235333"},
+		{ID: 1437723, Name: "Ana Lima", URL: "https://www.pexels.com/@ana-lima-1437723"},
+	}
+	avgColors  = []string{"#552313", "#401603", "#97694F", "#2E4A5C", "#7A8B6F"}
+	dimensions = [][2]uint16{{5108, 2874}, {3286, 4107}, {4000, 4000}, {4668, 3112}}
+)
+
+// FixturePhotos returns n photos with realistic, deterministic data. The URL
+// of every photo contains a topic, such as "ocean-waves", which is what the
+// Server searches on.
+func FixturePhotos(n int) []pexels.Photo {
+	photos := make([]pexels.Photo, n)
+	for i := range photos {
+		id := uint64(1000000 + i)
+		topic := topics[i%len(topics)]
+		creator := creators[i%len(creators)]
+		dim := dimensions[i%len(dimensions)]
+		photos[i] = pexels.Photo{
+			ID:     id,
+			Width:  dim[0],
+			Height: dim[1],
+			URL: fmt.Sprintf("https://www.pexels.com/photo/%s-%d/",
+				strings.ReplaceAll(topic, " ", "-"), id),
+			Photographer:    creator.Name,
+			PhotographerURL: creator.URL,
+			PhotographerID:  creator.ID,
+			AvgColor:        avgColors[i%len(avgColors)],
+			Src:             photoSource(id),
+		}
+	}
+	return photos
+}
+
+func photoSource(id uint64) pexels.PhotoSource {
+	original := fmt.Sprintf(
+		"https://images.pexels.com/photos/%d/pexels-photo-%d.jpeg", id, id)
+	src := func(query string) string {
+		return original + "?auto=compress&cs=tinysrgb&" + query
+	}
+	return pexels.PhotoSource{
+		Original:  original,
+		Large2x:   src("dpr=2&h=650&w=940"),
+		Large:     src("h=650&w=940"),
+		Medium:    src("h=350"),
+		Small:     src("h=130"),
+		Portrait:  src("fit=crop&h=1200&w=800"),
+		Landscape: src("fit=crop&h=627&w=1200"),
+		Tiny:      src("dpr=1&fit=crop&h=200&w=280"),
+	}
+}
+
+// FixtureVideos returns n videos with realistic, deterministic data, each
+// having sd, hd and uhd files. Like FixturePhotos, the URL holds the topic.
+func FixtureVideos(n int) []pexels.Video {
+	videos := make([]pexels.Video, n)
+	for i := range videos {
+		id := uint64(2000000 + i)
+		topic := topics[i%len(topics)]
+		videos[i] = pexels.Video{
+			ID:     id,
+			Width:  1920,
+			Height: 1080,
+			URL: fmt.Sprintf("https://www.pexels.com/video/%s-%d/",
+				strings.ReplaceAll(topic, " ", "-"), id),
+			Image: fmt.Sprintf(
+				"https://images.pexels.com/videos/%d/free-video-%d.jpg", id, id),
+			Duration:      uint16(5 + i%55),
+			User:          creators[i%len(creators)],
+			VideoFiles:    videoFiles(id),
+			VideoPictures: videoPictures(id),
+		}
+	}
+	return videos
+}
+
+func videoFiles(id uint64) []pexels.VideoFile {
+	renditions := []struct {
+		quality       string
+		width, height uint16
+	}{
+		{"sd", 640, 360},
+		{"sd", 960, 540},
+		{"hd", 1280, 720},
+		{"hd", 1920, 1080},
+		{"uhd", 3840, 2160},
+	}
+	files := make([]pexels.VideoFile, len(renditions))
+	for i, r := range renditions {
+		files[i] = pexels.VideoFile{
+			ID:       id*10 + uint64(i),
+			Quality:  r.quality,
+			FileType: "video/mp4",
+			Width:    r.width,
+			Height:   r.height,
+			Link: fmt.Sprintf(
+				"https://videos.pexels.com/video-files/%d/%d-%s_%d_%d_25fps.mp4",
+				id, id, r.quality, r.width, r.height),
+		}
+	}
+	return files
+}
+
+func videoPictures(id uint64) []pexels.VideoPicture {
+	pictures := make([]pexels.VideoPicture, 3)
+	for i := range pictures {
+		pictures[i] = pexels.VideoPicture{
+			ID: id*10 + uint64(i),
+			Picture: fmt.Sprintf(
+				"https://images.pexels.com/videos/%d/pictures/preview-%d.jpg", id, i),
+			NR: uint8(i),
+		}
+	}
+	return pictures
+}
+
+// FixtureCollections returns collections made up of the given photos and
+// videos, alternating between the two so the Media is of mixed types.
+func FixtureCollections(
+	photos []pexels.Photo, videos []pexels.Video,
+) []FixtureCollection {
+	titles := []string{"Seascapes", "Wilderness", "Night Life"}
+	collections := make([]FixtureCollection, len(titles))
+	for i, title := range titles {
+		var media []pexels.Media
+		var nPhotos, nVideos uint16
+		for j := i; j < len(photos) || j < len(videos); j += len(titles) {
+			if j < len(photos) {
+				p := photos[j]
+				p.Type = string(pexels.TypePhoto)
+				media = append(media, &p)
+				nPhotos++
+			}
+			if j < len(videos) {
+				v := videos[j]
+				v.Type = string(pexels.TypeVideo)
+				media = append(media, &v)
+				nVideos++
+			}
+		}
+		collections[i] = FixtureCollection{
+			Collection: pexels.Collection{
+				ID:          fmt.Sprintf("col%04d", i+1),
+				Title:       title,
+				Description: "A collection of " + strings.ToLower(title),
+				Private:     i == len(titles)-1,
+				MediaCount:  nPhotos + nVideos,
+				PhotosCount: nPhotos,
+				VideosCount: nVideos,
+			},
+			Media: media,
+		}
+	}
+	return collections
+}
@@ -0,0 +1,380 @@
+// Package pexelstest provides a fake Pexels API server for testing code that
+// uses a pexels.Client without reaching the real API.
+package pexelstest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/j-mnr/pexels-go"
+)
+
+// APIKey is the API key clients made by Server.NewClient use.
+const APIKey = "pexelstest-api-key"
+
+// Server is an httptest.Server answering like the Pexels API. Its fixtures
+// can be replaced before any request is made, but must not be modified while
+// requests are being served.
+type Server struct {
+	*httptest.Server
+
+	Photos      []pexels.Photo
+	Videos      []pexels.Video
+	Collections []FixtureCollection
+
+	mu        sync.Mutex
+	limit     int
+	remaining int
+	reset     time.Time
+	latency   time.Duration
+	failures  []failure
+	requests  int
+}
+
+// failure is a response queued by FailNextWith.
+type failure struct {
+	status int
+	header http.Header
+}
+
+// NewServer starts a Server filled with fixture data and a monthly quota of
+// 20000 requests. It must be closed when it is no longer needed.
+func NewServer() *Server {
+	s := &Server{
+		Photos:    FixturePhotos(120),
+		Videos:    FixtureVideos(60),
+		limit:     20000,
+		remaining: 20000,
+		reset:     time.Now().Add(30 * 24 * time.Hour).Truncate(time.Second),
+	}
+	s.Collections = FixtureCollections(s.Photos[:12], s.Videos[:9])
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// NewClient returns a pexels.Client that sends its requests to the Server.
+func (s *Server) NewClient(opts ...pexels.Option) (*pexels.Client, error) {
+	opts = append([]pexels.Option{pexels.WithHTTPClient(s.Client())}, opts...)
+	c, err := pexels.New(APIKey, opts...)
+	if err != nil {
+		return nil, err
+	}
+	c.RootPhotoURL = s.URL + "/v1"
+	c.RootVideoURL = s.URL + "/videos"
+	return c, nil
+}
+
+// FailNext makes the next n requests fail with status. A 429 is sent with a
+// Retry-After of one second, like Pexels does.
+func (s *Server) FailNext(n, status int) {
+	var header http.Header
+	if status == http.StatusTooManyRequests {
+		header = http.Header{"Retry-After": {"1"}}
+	}
+	s.FailNextWith(n, status, header)
+}
+
+// FailNextWith makes the next n requests fail with status, sending header on
+// top of the X-Ratelimit headers, which it may override.
+func (s *Server) FailNextWith(n, status int, header http.Header) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := 0; i < n; i++ {
+		s.failures = append(s.failures, failure{status, header.Clone()})
+	}
+}
+
+// SetLatency delays every response by d, or until the request is canceled.
+func (s *Server) SetLatency(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latency = d
+}
+
+// SetQuota sets the monthly quota reported in the X-Ratelimit headers. Once
+// remaining reaches zero every request fails with 429 Too Many Requests.
+func (s *Server) SetQuota(limit, remaining int, reset time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.limit, s.remaining, s.reset = limit, remaining, reset
+}
+
+// Requests returns how many requests the Server received.
+func (s *Server) Requests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.requests++
+	latency := s.latency
+	var fail failure
+	if len(s.failures) > 0 {
+		fail, s.failures = s.failures[0], s.failures[1:]
+	}
+	if s.remaining > 0 {
+		s.remaining--
+	} else if fail.status == 0 {
+		fail.status = http.StatusTooManyRequests
+		fail.header = http.Header{"Retry-After": {"1"}}
+	}
+	h := w.Header()
+	h.Set("X-Ratelimit-Limit", strconv.Itoa(s.limit))
+	h.Set("X-Ratelimit-Remaining", strconv.Itoa(s.remaining))
+	h.Set("X-Ratelimit-Reset", strconv.FormatInt(s.reset.Unix(), 10))
+	s.mu.Unlock()
+
+	if latency > 0 {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-time.After(latency):
+		}
+	}
+	switch {
+	case r.Header.Get("Authorization") == "":
+		writeError(w, http.StatusUnauthorized)
+		return
+	case fail.status != 0:
+		for k, v := range fail.header {
+			h[k] = v
+		}
+		writeError(w, fail.status)
+		return
+	case r.Method != http.MethodGet:
+		writeError(w, http.StatusMethodNotAllowed)
+		return
+	}
+	s.route(w, r)
+}
+
+func (s *Server) route(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimSuffix(r.URL.Path, "/")
+	switch {
+	case strings.HasPrefix(path, "/v1/photos/"):
+		s.photo(w, strings.TrimPrefix(path, "/v1/photos/"))
+	case path == "/v1/search":
+		s.searchPhotos(w, r)
+	case path == "/v1/curated":
+		writePage(w, r, "photos", s.Photos)
+	case strings.HasPrefix(path, "/videos/videos/"):
+		s.video(w, strings.TrimPrefix(path, "/videos/videos/"))
+	case path == "/videos/search":
+		s.searchVideos(w, r)
+	case path == "/videos/popular":
+		s.popularVideos(w, r)
+	case path == "/v1/collections":
+		s.collections(w, r)
+	case strings.HasPrefix(path, "/v1/collections/"):
+		s.collection(w, r, strings.TrimPrefix(path, "/v1/collections/"))
+	default:
+		writeError(w, http.StatusNotFound)
+	}
+}
+
+func (s *Server) photo(w http.ResponseWriter, rawID string) {
+	id, _ := strconv.ParseUint(rawID, 10, 64)
+	for _, p := range s.Photos {
+		if p.ID == id {
+			writeJSON(w, p)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound)
+}
+
+func (s *Server) video(w http.ResponseWriter, rawID string) {
+	id, _ := strconv.ParseUint(rawID, 10, 64)
+	for _, v := range s.Videos {
+		if v.ID == id {
+			writeJSON(w, v)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound)
+}
+
+func (s *Server) searchPhotos(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	if q.Get("query") == "" {
+		writeError(w, http.StatusBadRequest)
+		return
+	}
+	var found []pexels.Photo
+	for _, p := range s.Photos {
+		if matches(p.URL, q.Get("query")) &&
+			hasOrientation(q.Get("orientation"), p.Width, p.Height) {
+			found = append(found, p)
+		}
+	}
+	writePage(w, r, "photos", found)
+}
+
+func (s *Server) searchVideos(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	if q.Get("query") == "" {
+		writeError(w, http.StatusBadRequest)
+		return
+	}
+	var found []pexels.Video
+	for _, v := range s.Videos {
+		if matches(v.URL, q.Get("query")) &&
+			hasOrientation(q.Get("orientation"), v.Width, v.Height) {
+			found = append(found, v)
+		}
+	}
+	writePage(w, r, "videos", found)
+}
+
+func (s *Server) popularVideos(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	minWidth, _ := strconv.Atoi(q.Get("min_width"))
+	minHeight, _ := strconv.Atoi(q.Get("min_height"))
+	minDuration, _ := strconv.Atoi(q.Get("min_duration"))
+	maxDuration, _ := strconv.Atoi(q.Get("max_duration"))
+	var found []pexels.Video
+	for _, v := range s.Videos {
+		if int(v.Width) >= minWidth && int(v.Height) >= minHeight &&
+			int(v.Duration) >= minDuration &&
+			(maxDuration == 0 || int(v.Duration) <= maxDuration) {
+			found = append(found, v)
+		}
+	}
+	writePage(w, r, "videos", found)
+}
+
+func (s *Server) collections(w http.ResponseWriter, r *http.Request) {
+	cs := make([]pexels.Collection, len(s.Collections))
+	for i, fc := range s.Collections {
+		cs[i] = fc.Collection
+	}
+	writePage(w, r, "collections", cs)
+}
+
+func (s *Server) collection(w http.ResponseWriter, r *http.Request, id string) {
+	for _, fc := range s.Collections {
+		if fc.Collection.ID != id {
+			continue
+		}
+		media := fc.Media
+		if typ := r.URL.Query().Get("type"); typ != "" {
+			media = nil
+			for _, m := range fc.Media {
+				if mediaKind(m) == typ {
+					media = append(media, m)
+				}
+			}
+		}
+		writePage(w, r, "media", media, "id", id)
+		return
+	}
+	writeError(w, http.StatusNotFound)
+}
+
+// mediaKind is the value of the type query param that selects m.
+func mediaKind(m pexels.Media) string {
+	switch m.(type) {
+	case *pexels.Photo:
+		return "photos"
+	case *pexels.Video:
+		return "videos"
+	}
+	return ""
+}
+
+// matches reports whether every word of query is found in the URL slug.
+func matches(pageURL, query string) bool {
+	pageURL = strings.ToLower(pageURL)
+	for _, word := range strings.Fields(strings.ToLower(query)) {
+		if !strings.Contains(pageURL, word) {
+			return false
+		}
+	}
+	return true
+}
+
+func hasOrientation(orientation string, width, height uint16) bool {
+	switch orientation {
+	case "landscape":
+		return width > height
+	case "portrait":
+		return width < height
+	case "square":
+		return width == height
+	}
+	return true
+}
+
+// writePage writes the page of items asked for by r under key, along with the
+// pagination fields and links Pexels sends. Extra key value pairs are added to
+// the body as is.
+func writePage[T any](
+	w http.ResponseWriter, r *http.Request, key string, items []T, extra ...any,
+) {
+	q := r.URL.Query()
+	page, _ := strconv.Atoi(q.Get("page"))
+	if page < 1 {
+		page = 1
+	}
+	perPage, _ := strconv.Atoi(q.Get("per_page"))
+	if perPage < 1 {
+		perPage = 15
+	}
+	if perPage > 80 {
+		perPage = 80
+	}
+	start := min((page-1)*perPage, len(items))
+	end := min(start+perPage, len(items))
+
+	body := map[string]any{
+		key:             items[start:end],
+		"page":          page,
+		"per_page":      perPage,
+		"total_results": len(items),
+	}
+	if end < len(items) {
+		body["next_page"] = pageLink(r, page+1)
+	}
+	if page > 1 {
+		body["prev_page"] = pageLink(r, page-1)
+	}
+	for i := 0; i+1 < len(extra); i += 2 {
+		body[extra[i].(string)] = extra[i+1]
+	}
+	writeJSON(w, body)
+}
+
+// pageLink is the URL of another page of the same request, formatted the same
+// way Pexels formats them.
+func pageLink(r *http.Request, page int) string {
+	q := r.URL.Query()
+	q.Set("page", strconv.Itoa(page))
+	u := url.URL{
+		Scheme:   "http",
+		Host:     r.Host,
+		Path:     strings.TrimSuffix(r.URL.Path, "/") + "/",
+		RawQuery: q.Encode(),
+	}
+	return u.String()
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{
+		"error": http.StatusText(status),
+	})
+}
@@ -0,0 +1,321 @@
+package pexelstest_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/j-mnr/pexels-go"
+	"github.com/j-mnr/pexels-go/pexelstest"
+	"github.com/matryer/is"
+)
+
+func TestServerRoutes(t *testing.T) {
+	srv := pexelstest.NewServer()
+	defer srv.Close()
+	c, err := srv.NewClient()
+	is.New(t).NoErr(err)
+
+	var landscape, wide int
+	for _, p := range srv.Photos {
+		if strings.Contains(p.URL, "ocean") && p.Width > p.Height {
+			landscape++
+		}
+	}
+	for _, v := range srv.Videos {
+		if v.Width >= 3000 {
+			wide++
+		}
+	}
+	col := srv.Collections[0].Collection
+
+	tests := map[string]struct {
+		do      func(ctx context.Context) (int, error)
+		want    int
+		wantErr error
+	}{
+		"photo": {
+			do: func(ctx context.Context) (int, error) {
+				pr, err := c.GetPhoto(ctx, srv.Photos[7].ID)
+				if err == nil && pr.Photo.ID != srv.Photos[7].ID {
+					t.Errorf("got photo %d", pr.Photo.ID)
+				}
+				return 1, err
+			},
+			want: 1,
+		},
+		"unknown photo": {
+			do: func(ctx context.Context) (int, error) {
+				_, err := c.GetPhoto(ctx, 1)
+				return 0, err
+			},
+			wantErr: pexels.ErrNotFound,
+		},
+		"video": {
+			do: func(ctx context.Context) (int, error) {
+				vr, err := c.GetVideo(ctx, srv.Videos[3].ID)
+				if err == nil && vr.Video.ID != srv.Videos[3].ID {
+					t.Errorf("got video %d", vr.Video.ID)
+				}
+				return 1, err
+			},
+			want: 1,
+		},
+		"search photos": {
+			do: func(ctx context.Context) (int, error) {
+				pr, err := c.SearchPhotos(ctx,
+					&pexels.PhotoSearchParams{Query: "ocean", PerPage: 80})
+				return len(pr.Payload.Photos), err
+			},
+			want: 20,
+		},
+		"search landscape photos": {
+			do: func(ctx context.Context) (int, error) {
+				pr, err := c.SearchPhotos(ctx, &pexels.PhotoSearchParams{
+					Query:   "ocean",
+					General: pexels.General{Orientation: pexels.OrientationLandscape},
+					PerPage: 80,
+				})
+				return len(pr.Payload.Photos), err
+			},
+			want: landscape,
+		},
+		"search videos": {
+			do: func(ctx context.Context) (int, error) {
+				vr, err := c.SearchVideos(ctx,
+					&pexels.VideoSearchParams{Query: "ocean", PerPage: 80})
+				return len(vr.Payload.Videos), err
+			},
+			want: 10,
+		},
+		"curated": {
+			do: func(ctx context.Context) (int, error) {
+				pr, err := c.GetCuratedPhotos(ctx, nil)
+				return len(pr.Payload.Photos), err
+			},
+			want: 15,
+		},
+		"popular": {
+			do: func(ctx context.Context) (int, error) {
+				vr, err := c.GetPopularVideos(ctx,
+					&pexels.PopularVideoParams{MinWidth: 3000, PerPage: 80})
+				return len(vr.Payload.Videos), err
+			},
+			want: wide,
+		},
+		"collections": {
+			do: func(ctx context.Context) (int, error) {
+				cr, err := c.GetCollections(ctx, nil)
+				return len(cr.Payload.Collections), err
+			},
+			want: 3,
+		},
+		"collection": {
+			do: func(ctx context.Context) (int, error) {
+				mr, err := c.GetCollection(ctx,
+					&pexels.CollectionMediaParams{ID: col.ID})
+				return len(mr.Media), err
+			},
+			want: int(col.MediaCount),
+		},
+		"collection photos": {
+			do: func(ctx context.Context) (int, error) {
+				mr, err := c.GetCollection(ctx,
+					&pexels.CollectionMediaParams{ID: col.ID, Type: "photos"})
+				if len(mr.Videos) > 0 {
+					t.Errorf("got %d videos", len(mr.Videos))
+				}
+				return len(mr.Photos), err
+			},
+			want: int(col.PhotosCount),
+		},
+		"unknown collection": {
+			do: func(ctx context.Context) (int, error) {
+				_, err := c.GetCollection(ctx,
+					&pexels.CollectionMediaParams{ID: "nope"})
+				return 0, err
+			},
+			wantErr: pexels.ErrNotFound,
+		},
+	}
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			is := is.New(t)
+			got, err := tc.do(context.Background())
+			if tc.wantErr != nil {
+				is.True(errors.Is(err, tc.wantErr))
+				return
+			}
+			is.NoErr(err)
+			is.Equal(got, tc.want)
+		})
+	}
+}
+
+func TestServerRejects(t *testing.T) {
+	srv := pexelstest.NewServer()
+	defer srv.Close()
+
+	tests := map[string]struct {
+		method string
+		auth   bool
+		want   int
+	}{
+		"no API key": {method: http.MethodGet, want: http.StatusUnauthorized},
+		"POST":       {method: http.MethodPost, auth: true, want: http.StatusMethodNotAllowed},
+		"GET":        {method: http.MethodGet, auth: true, want: http.StatusOK},
+	}
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			is := is.New(t)
+			req, err := http.NewRequest(tc.method, srv.URL+"/v1/curated", nil)
+			is.NoErr(err)
+			if tc.auth {
+				req.Header.Set("Authorization", pexelstest.APIKey)
+			}
+			resp, err := srv.Client().Do(req)
+			is.NoErr(err)
+			resp.Body.Close()
+			is.Equal(resp.StatusCode, tc.want)
+		})
+	}
+}
+
+func TestServerPagination(t *testing.T) {
+	is := is.New(t)
+	srv := pexelstest.NewServer()
+	defer srv.Close()
+	c, err := srv.NewClient()
+	is.NoErr(err)
+	ctx := context.Background()
+
+	first, err := c.SearchPhotos(ctx,
+		&pexels.PhotoSearchParams{Query: "ocean", PerPage: 8})
+	is.NoErr(err)
+	is.Equal(first.Payload.Page, uint16(1))
+	is.Equal(first.Payload.TotalResults, uint32(20))
+	is.Equal(first.Payload.PrevPage, "")
+	is.True(strings.HasPrefix(first.Payload.NextPage, srv.URL+"/v1/search/?"))
+
+	seen := map[uint64]bool{}
+	pages := []pexels.PhotosResponse{first}
+	for pr := first; pr.Payload.NextPage != ""; {
+		pr, err = c.NextPhotos(ctx, pr)
+		is.NoErr(err)
+		is.True(pr.Payload.PrevPage != "")
+		pages = append(pages, pr)
+	}
+	is.Equal(len(pages), 3)
+	for _, pr := range pages {
+		for _, p := range pr.Payload.Photos {
+			is.True(!seen[p.ID])
+			seen[p.ID] = true
+		}
+	}
+	is.Equal(len(seen), 20)
+
+	last := pages[len(pages)-1]
+	is.Equal(last.Payload.Page, uint16(3))
+	_, err = c.NextPhotos(ctx, last)
+	is.True(errors.Is(err, pexels.ErrNoPage))
+
+	prev, err := c.PrevPhotos(ctx, last)
+	is.NoErr(err)
+	is.Equal(prev.Payload.Photos, pages[1].Payload.Photos)
+
+	payload, _, err := pexels.FetchPage[pexels.PhotoPayload](
+		ctx, c, first.Payload.NextPage)
+	is.NoErr(err)
+	is.Equal(payload.Photos, pages[1].Payload.Photos)
+}
+
+func TestServerFailNext(t *testing.T) {
+	tests := map[string]struct {
+		status     int
+		wantErr    error
+		retryAfter string
+	}{
+		"server error": {status: http.StatusInternalServerError},
+		"rate limited": {
+			status:     http.StatusTooManyRequests,
+			wantErr:    pexels.ErrRateLimited,
+			retryAfter: "1",
+		},
+		"not found": {
+			status:  http.StatusNotFound,
+			wantErr: pexels.ErrNotFound,
+		},
+	}
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			is := is.New(t)
+			srv := pexelstest.NewServer()
+			defer srv.Close()
+			c, err := srv.NewClient()
+			is.NoErr(err)
+			ctx := context.Background()
+
+			srv.FailNext(2, tc.status)
+			for i := 0; i < 2; i++ {
+				_, err = c.GetPhoto(ctx, srv.Photos[0].ID)
+				var apiErr *pexels.APIError
+				is.True(errors.As(err, &apiErr))
+				is.Equal(apiErr.Common.StatusCode, tc.status)
+				is.Equal(apiErr.Common.Header.Get("Retry-After"), tc.retryAfter)
+				if tc.wantErr != nil {
+					is.True(errors.Is(err, tc.wantErr))
+				}
+			}
+			_, err = c.GetPhoto(ctx, srv.Photos[0].ID)
+			is.NoErr(err)
+			is.Equal(srv.Requests(), 3)
+		})
+	}
+}
+
+func TestServerSetLatency(t *testing.T) {
+	is := is.New(t)
+	srv := pexelstest.NewServer()
+	defer srv.Close()
+	c, err := srv.NewClient()
+	is.NoErr(err)
+
+	srv.SetLatency(time.Minute)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err = c.GetPhoto(ctx, srv.Photos[0].ID)
+	is.True(errors.Is(err, context.DeadlineExceeded))
+	is.True(time.Since(start) < 10*time.Second)
+
+	srv.SetLatency(30 * time.Millisecond)
+	start = time.Now()
+	_, err = c.GetPhoto(context.Background(), srv.Photos[0].ID)
+	is.NoErr(err)
+	is.True(time.Since(start) >= 30*time.Millisecond)
+}
+
+func TestServerSetQuota(t *testing.T) {
+	is := is.New(t)
+	srv := pexelstest.NewServer()
+	defer srv.Close()
+	c, err := srv.NewClient()
+	is.NoErr(err)
+	reset := time.Now().Add(time.Hour).Truncate(time.Second)
+	srv.SetQuota(100, 1, reset)
+
+	pr, err := c.GetPhoto(context.Background(), srv.Photos[0].ID)
+	is.NoErr(err)
+	is.Equal(pr.Common.GetRateLimit(), 100)
+	is.Equal(pr.Common.GetRateLimitRemaining(), 0)
+	is.Equal(pr.Common.GetRateLimitReset(), int(reset.Unix()))
+
+	_, err = c.GetPhoto(context.Background(), srv.Photos[0].ID)
+	is.True(errors.Is(err, pexels.ErrRateLimited))
+}
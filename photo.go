@@ -1,7 +1,9 @@
 package pexels
 
 import (
+	"context"
 	"fmt"
+	"net/url"
 )
 
 const (
@@ -55,6 +57,53 @@ type PhotoSource struct {
 	Tiny      string `json:"tiny"`      // W 280px X H 200px
 }
 
+// PhotoVariant is an enum; all of them start with "Variant". Each one names a
+// size found in PhotoSource.
+type PhotoVariant interface {
+	variant()
+	String() string
+}
+
+type variant string
+
+func (variant) variant() {}
+
+func (v variant) String() string { return string(v) }
+
+const (
+	VariantOriginal  variant = "original"
+	VariantLarge2x   variant = "large2x"
+	VariantLarge     variant = "large"
+	VariantMedium    variant = "medium"
+	VariantSmall     variant = "small"
+	VariantPortrait  variant = "portrait"
+	VariantLandscape variant = "landscape"
+	VariantTiny      variant = "tiny"
+)
+
+// URL returns the URL of the PhotoVariant v, or an empty string if v is nil.
+func (ps PhotoSource) URL(v PhotoVariant) string {
+	switch v {
+	case VariantOriginal:
+		return ps.Original
+	case VariantLarge2x:
+		return ps.Large2x
+	case VariantLarge:
+		return ps.Large
+	case VariantMedium:
+		return ps.Medium
+	case VariantSmall:
+		return ps.Small
+	case VariantPortrait:
+		return ps.Portrait
+	case VariantLandscape:
+		return ps.Landscape
+	case VariantTiny:
+		return ps.Tiny
+	}
+	return ""
+}
+
 // PhotoPayload is a slice of Photo with Pagination.
 type PhotoPayload struct {
 	Photos []Photo `json:"photos"`
@@ -71,27 +120,77 @@ type PhotosResponse struct {
 // CuratedPhotosParams allows you to pick which page in your collections you
 // start or how many per page you want.
 type CuratedPhotosParams struct {
-	Page    uint16 `query:"page,1"`
-	PerPage uint8  `query:"per_page,15"` // Max 80
+	Page    uint16 // Default: 1
+	PerPage uint8  // Default: 15, Max: 80
+}
+
+// Encode returns the URL encoded query string of the params.
+func (p *CuratedPhotosParams) Encode() string {
+	if p == nil {
+		return ""
+	}
+	q := url.Values{}
+	setUint(q, "page", p.Page, 1)
+	setUint(q, "per_page", p.PerPage, 15)
+	return q.Encode()
+}
+
+// Validate reports every field of the params Pexels would reject.
+func (p *CuratedPhotosParams) Validate() error {
+	if p == nil {
+		return nil
+	}
+	var fe fieldErrors
+	fe.checkPerPage(p.PerPage)
+	return fe.err()
 }
 
 // PhotoSearchParams requires Query. It has all of the available parameters
 // by which you can search for a photo.
 type PhotoSearchParams struct {
-	Query string `query:"query"` // Query is required
+	Query string // Query is required
 
 	General
-	// the supported pexels colors which you can search with are:
-	// red, orange, yellow, green, turquoise, blue, violet, pink, brown, black,
-	// gray, white.
-	Color   string `query:"color"`
-	Page    uint16 `query:"page,1"`
-	PerPage uint8  `query:"per_page,15"` // Max: 80
+	// Color is one of the Color enums or any hexadecimal color made with
+	// HexColor.
+	Color   Color
+	Page    uint16 // Default: 1
+	PerPage uint8  // Default: 15, Max: 80
+}
+
+// Encode returns the URL encoded query string of the params.
+func (p *PhotoSearchParams) Encode() string {
+	if p == nil {
+		return ""
+	}
+	q := url.Values{}
+	setString(q, "query", p.Query)
+	p.General.encode(q)
+	setEnum(q, "color", p.Color)
+	setUint(q, "page", p.Page, 1)
+	setUint(q, "per_page", p.PerPage, 15)
+	return q.Encode()
+}
+
+// Validate reports every field of the params Pexels would reject, including a
+// missing Query.
+func (p *PhotoSearchParams) Validate() error {
+	if p == nil {
+		return &FieldError{Field: "Query", Value: "", Err: ErrMissingQuery}
+	}
+	var fe fieldErrors
+	if p.Query == "" {
+		fe.add("Query", p.Query, ErrMissingQuery)
+	}
+	fe.checkPerPage(p.PerPage)
+	return fe.err()
 }
 
 // GetPhoto retreives a photo by its ID found at the end of its URL.
-func (c *Client) GetPhoto(photoID uint64) (PhotoResponse, error) {
-	resp, err := get(*c, fmt.Sprint(photoEndpoint, photoID), "", &Photo{})
+func (c *Client) GetPhoto(
+	ctx context.Context, photoID uint64,
+) (PhotoResponse, error) {
+	resp, err := get(ctx, *c, fmt.Sprint(photoEndpoint, photoID), nil, &Photo{})
 	if err != nil {
 		return PhotoResponse{}, err
 	}
@@ -105,9 +204,12 @@ func (c *Client) GetPhoto(photoID uint64) (PhotoResponse, error) {
 // Pexels. If nil is passed it will default to the first page and return 15
 // photos.
 func (c *Client) GetCuratedPhotos(
-	cpp *CuratedPhotosParams,
+	ctx context.Context, cpp *CuratedPhotosParams,
 ) (PhotosResponse, error) {
-	resp, err := get(*c, curatedPhotosEndpoint, cpp, &PhotoPayload{})
+	if err := cpp.Validate(); err != nil {
+		return PhotosResponse{}, err
+	}
+	resp, err := get(ctx, *c, curatedPhotosEndpoint, cpp, &PhotoPayload{})
 	if err != nil {
 		return PhotosResponse{}, err
 	}
@@ -118,14 +220,14 @@ func (c *Client) GetCuratedPhotos(
 
 // SearchPhotos returns a slice of Photos 15 photos by default.
 // The PhotoSearchParams.Query is required and SearchPhotos will return an
-// error if it is nil.
+// error if it is empty, or if any other field does not pass Validate.
 func (c *Client) SearchPhotos(
-	psp *PhotoSearchParams,
+	ctx context.Context, psp *PhotoSearchParams,
 ) (PhotosResponse, error) {
-	if psp == nil || psp.Query == "" {
-		return PhotosResponse{}, ErrMissingQuery
+	if err := psp.Validate(); err != nil {
+		return PhotosResponse{}, err
 	}
-	resp, err := get(*c, searchPhotosEndpoint, psp, &PhotoPayload{})
+	resp, err := get(ctx, *c, searchPhotosEndpoint, psp, &PhotoPayload{})
 	if err != nil {
 		return PhotosResponse{}, err
 	}
@@ -0,0 +1,37 @@
+package pexels
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// queryEncoder is implemented by every params struct; Encode returns the URL
+// encoded query string of the params with zero values left out.
+type queryEncoder interface {
+	Encode() string
+}
+
+func setString(q url.Values, key, v string) {
+	if v != "" {
+		q.Set(key, v)
+	}
+}
+
+// setUint sets v under key, or def if v is zero. Nothing is set when both are
+// zero.
+func setUint[T ~uint8 | ~uint16](q url.Values, key string, v, def T) {
+	if v == 0 {
+		v = def
+	}
+	if v != 0 {
+		q.Set(key, strconv.FormatUint(uint64(v), 10))
+	}
+}
+
+// setEnum sets one of the sealed enums, which are left out when nil.
+func setEnum(q url.Values, key string, v fmt.Stringer) {
+	if v != nil {
+		setString(q, key, v.String())
+	}
+}
@@ -0,0 +1,119 @@
+package pexels_test
+
+import (
+	"testing"
+
+	"github.com/j-mnr/pexels-go"
+)
+
+type encodeTest struct {
+	params interface{ Encode() string }
+	want   string
+}
+
+func runEncodeTests(t *testing.T, tests map[string]encodeTest) {
+	t.Helper()
+	for name, tc := range tests {
+		if got := tc.params.Encode(); got != tc.want {
+			t.Errorf("%s: Encode() = %q, want %q", name, got, tc.want)
+		}
+	}
+}
+
+func TestCuratedPhotosParamsEncode(t *testing.T) {
+	runEncodeTests(t, map[string]encodeTest{
+		"nil":  {(*pexels.CuratedPhotosParams)(nil), ""},
+		"zero": {&pexels.CuratedPhotosParams{}, "page=1&per_page=15"},
+		"set":  {&pexels.CuratedPhotosParams{Page: 3, PerPage: 40}, "page=3&per_page=40"},
+	})
+}
+
+func TestPhotoSearchParamsEncode(t *testing.T) {
+	runEncodeTests(t, map[string]encodeTest{
+		"nil": {(*pexels.PhotoSearchParams)(nil), ""},
+		"query only": {
+			&pexels.PhotoSearchParams{Query: "blue sky"},
+			"page=1&per_page=15&query=blue+sky",
+		},
+		"some of General": {
+			&pexels.PhotoSearchParams{
+				Query:   "sea",
+				General: pexels.General{Size: pexels.SizeLarge},
+			},
+			"page=1&per_page=15&query=sea&size=large",
+		},
+		"everything": {
+			&pexels.PhotoSearchParams{
+				Query: "sea",
+				General: pexels.General{
+					Locale:      pexels.LocaleEN_US,
+					Orientation: pexels.OrientationLandscape,
+					Size:        pexels.SizeLarge,
+				},
+				Color:   pexels.ColorRed,
+				Page:    2,
+				PerPage: 80,
+			},
+			"color=red&locale=en-US&orientation=landscape&page=2&per_page=80" +
+				"&query=sea&size=large",
+		},
+	})
+}
+
+func TestVideoSearchParamsEncode(t *testing.T) {
+	runEncodeTests(t, map[string]encodeTest{
+		"nil":        {(*pexels.VideoSearchParams)(nil), ""},
+		"query only": {&pexels.VideoSearchParams{Query: "waves"}, "page=1&per_page=15&query=waves"},
+		"everything": {
+			&pexels.VideoSearchParams{
+				Query: "waves",
+				General: pexels.General{
+					Locale:      pexels.LocalePT_BR,
+					Orientation: pexels.OrientationPortrait,
+					Size:        pexels.SizeSmall,
+				},
+				Page:    4,
+				PerPage: 10,
+			},
+			"locale=pt-BR&orientation=portrait&page=4&per_page=10&query=waves&size=small",
+		},
+	})
+}
+
+func TestPopularVideoParamsEncode(t *testing.T) {
+	runEncodeTests(t, map[string]encodeTest{
+		"nil":  {(*pexels.PopularVideoParams)(nil), ""},
+		"zero": {&pexels.PopularVideoParams{}, "page=1&per_page=15"},
+		"min width only": {
+			&pexels.PopularVideoParams{MinWidth: 1920},
+			"min_width=1920&page=1&per_page=15",
+		},
+		"everything": {
+			&pexels.PopularVideoParams{
+				MinWidth: 1920, MinHeight: 1080, MinDuration: 5, MaxDuration: 60,
+				Page: 2, PerPage: 30,
+			},
+			"max_duration=60&min_duration=5&min_height=1080&min_width=1920" +
+				"&page=2&per_page=30",
+		},
+	})
+}
+
+func TestCollectionParamsEncode(t *testing.T) {
+	runEncodeTests(t, map[string]encodeTest{
+		"nil":  {(*pexels.CollectionParams)(nil), ""},
+		"zero": {&pexels.CollectionParams{}, "page=1&per_page=15"},
+		"set":  {&pexels.CollectionParams{Page: 5, PerPage: 1}, "page=5&per_page=1"},
+	})
+}
+
+func TestCollectionMediaParamsEncode(t *testing.T) {
+	runEncodeTests(t, map[string]encodeTest{
+		"nil":     {(*pexels.CollectionMediaParams)(nil), ""},
+		"ID only": {&pexels.CollectionMediaParams{ID: "abc"}, "page=1&per_page=15"},
+		"type": {
+			&pexels.CollectionMediaParams{ID: "abc", Type: "photos", PerPage: 80},
+			"page=1&per_page=80&type=photos",
+		},
+	})
+}
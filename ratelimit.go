@@ -0,0 +1,161 @@
+package pexels
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var ErrLimitExceeded = errors.New("the client-side rate limit would be exceeded")
+
+// LimitMode is what the rate limiter does when a request is over budget.
+type LimitMode int
+
+const (
+	// LimitBlock waits until the request fits within the budget or the
+	// context is done.
+	LimitBlock LimitMode = iota
+	// LimitFailFast returns ErrLimitExceeded straight away.
+	LimitFailFast
+)
+
+// RateLimit configures the opt-in client-side rate limiter.
+type RateLimit struct {
+	// Requests is how many requests may be made every Interval, e.g. 200 every
+	// time.Hour. Zero leaves the request rate unlimited and only the monthly
+	// quota reported by Pexels is enforced.
+	Requests int
+	Interval time.Duration
+	Mode     LimitMode
+	// Reserve is how many requests of the monthly quota are held back for
+	// interactive traffic. Requests whose context was not marked with
+	// Interactive stop once the remaining quota reaches Reserve.
+	Reserve int
+}
+
+// Quota is the latest state of the monthly quota as reported by the
+// X-Ratelimit headers of any response.
+type Quota struct {
+	Limit     int
+	Remaining int
+	Reset     time.Time
+}
+
+// WithRateLimit makes the Client keep track of the quota headers Pexels sends
+// back and hold requests to the budget given by RateLimit.
+func WithRateLimit(rl RateLimit) Option {
+	return func(cl *Client) {
+		cl.limiter = &limiter{cfg: rl, tokens: float64(rl.Requests)}
+	}
+}
+
+type interactiveKey struct{}
+
+// Interactive marks ctx as belonging to interactive traffic, which is allowed
+// to use the requests held back by RateLimit.Reserve.
+func Interactive(ctx context.Context) context.Context {
+	return context.WithValue(ctx, interactiveKey{}, true)
+}
+
+func isInteractive(ctx context.Context) bool {
+	v, _ := ctx.Value(interactiveKey{}).(bool)
+	return v
+}
+
+// Quota returns the latest monthly quota seen by the Client. It reports false
+// if the Client has no rate limiter or no quota headers were received yet.
+func (c *Client) Quota() (Quota, bool) {
+	if c.limiter == nil {
+		return Quota{}, false
+	}
+	c.limiter.mu.Lock()
+	defer c.limiter.mu.Unlock()
+	return c.limiter.quota, c.limiter.known
+}
+
+type limiter struct {
+	mu     sync.Mutex
+	cfg    RateLimit
+	tokens float64
+	last   time.Time
+	quota  Quota
+	known  bool
+}
+
+// wait blocks until a request may be sent, or returns an error if it may not
+// be sent at all.
+func (l *limiter) wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+	for {
+		d, err := l.reserve(ctx)
+		if err != nil || d == 0 {
+			return err
+		}
+		if err := sleep(ctx, d); err != nil {
+			return err
+		}
+	}
+}
+
+// reserve takes a request out of the budget, otherwise it returns how long to
+// wait before trying again.
+func (l *limiter) reserve(ctx context.Context) (time.Duration, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	floor := l.cfg.Reserve
+	if isInteractive(ctx) {
+		floor = 0
+	}
+	if l.known && l.quota.Remaining <= floor && now.Before(l.quota.Reset) {
+		if l.cfg.Mode == LimitFailFast {
+			return 0, ErrLimitExceeded
+		}
+		return l.quota.Reset.Sub(now), nil
+	}
+
+	if l.cfg.Requests > 0 && l.cfg.Interval > 0 {
+		rate := float64(l.cfg.Requests) / float64(l.cfg.Interval)
+		if !l.last.IsZero() {
+			l.tokens += float64(now.Sub(l.last)) * rate
+		}
+		if burst := float64(l.cfg.Requests); l.tokens > burst {
+			l.tokens = burst
+		}
+		l.last = now
+		if l.tokens < 1 {
+			if l.cfg.Mode == LimitFailFast {
+				return 0, ErrLimitExceeded
+			}
+			return time.Duration((1 - l.tokens) / rate), nil
+		}
+		l.tokens--
+	}
+	if l.known {
+		// Count the request against the quota now so concurrent callers do
+		// not all slip past the reserve before any response comes back.
+		l.quota.Remaining--
+	}
+	return 0, nil
+}
+
+// observe records the quota headers of a response.
+func (l *limiter) observe(h http.Header) {
+	if l == nil || h.Get("X-Ratelimit-Remaining") == "" {
+		return
+	}
+	rc := ResponseCommon{Header: h}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.quota = Quota{
+		Limit:     rc.GetRateLimit(),
+		Remaining: rc.GetRateLimitRemaining(),
+		Reset:     time.Unix(int64(rc.GetRateLimitReset()), 0),
+	}
+	l.known = true
+}
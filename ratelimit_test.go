@@ -0,0 +1,152 @@
+package pexels_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/j-mnr/pexels-go"
+	"github.com/j-mnr/pexels-go/pexelstest"
+	"github.com/matryer/is"
+)
+
+func TestRateLimitQuota(t *testing.T) {
+	is := is.New(t)
+	srv := pexelstest.NewServer()
+	defer srv.Close()
+	reset := time.Now().Add(time.Hour).Truncate(time.Second)
+	srv.SetQuota(100, 50, reset)
+
+	c, err := srv.NewClient()
+	is.NoErr(err)
+	_, err = c.GetPhoto(context.Background(), srv.Photos[0].ID)
+	is.NoErr(err)
+	_, ok := c.Quota()
+	is.True(!ok) // no rate limiter
+
+	c, err = srv.NewClient(pexels.WithRateLimit(pexels.RateLimit{}))
+	is.NoErr(err)
+	_, ok = c.Quota()
+	is.True(!ok) // nothing received yet
+	_, err = c.GetPhoto(context.Background(), srv.Photos[0].ID)
+	is.NoErr(err)
+	quota, ok := c.Quota()
+	is.True(ok)
+	is.Equal(quota.Limit, 100)
+	is.Equal(quota.Remaining, 48)
+	is.True(quota.Reset.Equal(reset))
+}
+
+func TestRateLimitRequests(t *testing.T) {
+	is := is.New(t)
+	srv := pexelstest.NewServer()
+	defer srv.Close()
+	c, err := srv.NewClient(pexels.WithRateLimit(pexels.RateLimit{
+		Requests: 2, Interval: 200 * time.Millisecond, Mode: pexels.LimitFailFast,
+	}))
+	is.NoErr(err)
+	ctx := context.Background()
+	get := func() error {
+		_, err := c.GetPhoto(ctx, srv.Photos[0].ID)
+		return err
+	}
+
+	is.NoErr(get())
+	is.NoErr(get())
+	is.True(errors.Is(get(), pexels.ErrLimitExceeded))
+	is.Equal(srv.Requests(), 2)
+
+	// A token is back after a tenth of a second.
+	time.Sleep(150 * time.Millisecond)
+	is.NoErr(get())
+	is.True(errors.Is(get(), pexels.ErrLimitExceeded))
+	is.Equal(srv.Requests(), 3)
+}
+
+func TestRateLimitBlocks(t *testing.T) {
+	is := is.New(t)
+	srv := pexelstest.NewServer()
+	defer srv.Close()
+	c, err := srv.NewClient(pexels.WithRateLimit(pexels.RateLimit{
+		Requests: 1, Interval: 100 * time.Millisecond,
+	}))
+	is.NoErr(err)
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		_, err := c.GetPhoto(context.Background(), srv.Photos[0].ID)
+		is.NoErr(err)
+	}
+	is.True(time.Since(start) >= 150*time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = c.GetPhoto(ctx, srv.Photos[0].ID)
+	is.True(errors.Is(err, context.DeadlineExceeded))
+	is.Equal(srv.Requests(), 3)
+}
+
+func TestRateLimitReserve(t *testing.T) {
+	tests := map[string]struct {
+		mode        pexels.LimitMode
+		interactive bool
+		wantErr     error
+	}{
+		"batch fails fast": {
+			mode: pexels.LimitFailFast, wantErr: pexels.ErrLimitExceeded,
+		},
+		"batch blocks": {
+			mode: pexels.LimitBlock, wantErr: context.DeadlineExceeded,
+		},
+		"interactive": {mode: pexels.LimitFailFast, interactive: true},
+	}
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			is := is.New(t)
+			srv := pexelstest.NewServer()
+			defer srv.Close()
+			srv.SetQuota(100, 3, time.Now().Add(time.Hour))
+			c, err := srv.NewClient(pexels.WithRateLimit(pexels.RateLimit{
+				Mode: tc.mode, Reserve: 2,
+			}))
+			is.NoErr(err)
+			// Learn the quota, which leaves the 2 reserved requests.
+			_, err = c.GetPhoto(context.Background(), srv.Photos[0].ID)
+			is.NoErr(err)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+			defer cancel()
+			if tc.interactive {
+				ctx = pexels.Interactive(ctx)
+			}
+			_, err = c.GetPhoto(ctx, srv.Photos[0].ID)
+			if tc.wantErr != nil {
+				is.True(errors.Is(err, tc.wantErr))
+				is.Equal(srv.Requests(), 1)
+				return
+			}
+			is.NoErr(err)
+			is.Equal(srv.Requests(), 2)
+		})
+	}
+}
+
+func TestRateLimitQuotaUsedUp(t *testing.T) {
+	is := is.New(t)
+	srv := pexelstest.NewServer()
+	defer srv.Close()
+	srv.SetQuota(100, 1, time.Now().Add(time.Hour))
+	c, err := srv.NewClient(pexels.WithRateLimit(pexels.RateLimit{
+		Mode: pexels.LimitFailFast,
+	}))
+	is.NoErr(err)
+	ctx := pexels.Interactive(context.Background())
+
+	_, err = c.GetPhoto(ctx, srv.Photos[0].ID)
+	is.NoErr(err)
+	_, err = c.GetPhoto(ctx, srv.Photos[0].ID)
+	is.True(errors.Is(err, pexels.ErrLimitExceeded))
+	is.Equal(srv.Requests(), 1)
+}
@@ -10,6 +10,9 @@ type ResponseCommon struct {
 	StatusCode int         `json:"status_code"` //nolint:tagliatelle
 	Status     string      `json:"status"`
 	Header     http.Header `json:"headers"`
+	// CacheHit is true when the response was served from the Cache given to
+	// WithCache, including responses Pexels confirmed were not modified.
+	CacheHit bool `json:"cache_hit"` //nolint:tagliatelle
 }
 
 func (ResponseCommon) convertHeaderToInt(h string) int {
@@ -43,4 +46,5 @@ func (r *response[any]) copyCommon(rc *ResponseCommon) {
 	rc.StatusCode = r.Common.StatusCode
 	rc.Header = r.Common.Header
 	rc.Status = r.Common.Status
+	rc.CacheHit = r.Common.CacheHit
 }
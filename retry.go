@@ -0,0 +1,194 @@
+package pexels
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy decides if and when a failed request is sent again. The zero
+// value never retries.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts made, including the first.
+	MaxAttempts int
+	// BaseBackoff is the wait before the first retry, it doubles with every
+	// following attempt.
+	BaseBackoff time.Duration
+	// MaxBackoff caps the wait between attempts. When Pexels asks to wait
+	// longer than this, through Retry-After or X-Ratelimit-Reset, the request
+	// is not retried at all. Zero means no cap.
+	MaxBackoff time.Duration
+	// Jitter is the fraction, between 0 and 1, of each backoff that is
+	// randomly taken off to spread out retries of concurrent callers.
+	Jitter float64
+	// Retryable reports whether a response with the status code should be
+	// retried. If nil RetryableStatus is used.
+	Retryable func(statusCode int) bool
+}
+
+// DefaultRetryPolicy returns the RetryPolicy used by WithRetry when you do
+// not need anything special: three attempts, starting at half a second and
+// never waiting more than thirty seconds.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 3,
+		BaseBackoff: 500 * time.Millisecond,
+		MaxBackoff:  30 * time.Second,
+		Jitter:      0.2,
+	}
+}
+
+// RetryableStatus reports true for 429 Too Many Requests and any 5xx status
+// code other than 501 Not Implemented.
+func RetryableStatus(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests ||
+		(statusCode >= 500 && statusCode != http.StatusNotImplemented)
+}
+
+// WithRetry retries requests that fail with a transient network error or a
+// status code deemed retryable by the RetryPolicy.
+func WithRetry(p RetryPolicy) Option {
+	return func(cl *Client) { cl.retry = p }
+}
+
+func (p RetryPolicy) retryable(statusCode int) bool {
+	if p.Retryable == nil {
+		return RetryableStatus(statusCode)
+	}
+	return p.Retryable(statusCode)
+}
+
+// backoff is the exponential wait before the attempt following attempt.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.BaseBackoff
+	for i := 1; i < attempt && (p.MaxBackoff == 0 || d < p.MaxBackoff); i++ {
+		d *= 2
+	}
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	if p.Jitter > 0 {
+		d -= time.Duration(float64(d) * p.Jitter * rand.Float64()) //nolint:gosec
+	}
+	return d
+}
+
+// delay is how long to wait before retrying resp. The second return value is
+// false when Pexels asked for a longer wait than MaxBackoff allows.
+func (p RetryPolicy) delay(attempt int, resp *http.Response) (time.Duration, bool) {
+	wait, ok := serverDelay(resp)
+	if !ok {
+		return p.backoff(attempt), true
+	}
+	if p.MaxBackoff > 0 && wait > p.MaxBackoff {
+		return 0, false
+	}
+	return wait, true
+}
+
+// serverDelay reads how long Pexels wants us to wait from the Retry-After
+// header or, when the monthly quota is used up, from X-Ratelimit-Reset.
+func serverDelay(resp *http.Response) (time.Duration, bool) {
+	if ra := resp.Header.Get("Retry-After"); ra != "" {
+		if secs, err := strconv.Atoi(ra); err == nil {
+			return time.Duration(secs) * time.Second, true
+		}
+		if t, err := http.ParseTime(ra); err == nil {
+			return time.Until(t), true
+		}
+	}
+	rc := ResponseCommon{Header: resp.Header}
+	if resp.StatusCode != http.StatusTooManyRequests ||
+		rc.Header.Get("X-Ratelimit-Remaining") != "0" {
+		return 0, false
+	}
+	if reset := rc.GetRateLimitReset(); reset > 0 {
+		return time.Until(time.Unix(int64(reset), 0)), true
+	}
+	return 0, false
+}
+
+// send does the request, retrying according to the Client's RetryPolicy.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	for attempt := 1; ; attempt++ {
+		last := attempt >= c.retry.MaxAttempts
+		if err := c.limiter.wait(ctx); err != nil {
+			return nil, err
+		}
+		info := RequestInfo{
+			Method:  req.Method,
+			URL:     redactURL(req.URL),
+			Attempt: attempt,
+		}
+		c.before(ctx, info)
+		start := time.Now()
+		resp, err := c.client.Do(req)
+		c.after(ctx, info, start, resp, err)
+		if err == nil {
+			c.limiter.observe(resp.Header)
+		}
+		var wait time.Duration
+		switch {
+		case err != nil:
+			if last || ctx.Err() != nil || !isTransient(err) {
+				return nil, err
+			}
+			wait = c.retry.backoff(attempt)
+		case !last && c.retry.retryable(resp.StatusCode):
+			var ok bool
+			if wait, ok = c.retry.delay(attempt, resp); !ok {
+				return resp, nil
+			}
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxErrorBody))
+			resp.Body.Close()
+		default:
+			return resp, nil
+		}
+		if err := sleep(ctx, wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// isTransient reports whether a transport error is likely to go away if the
+// request is sent again.
+func isTransient(err error) bool {
+	if errors.Is(err, context.Canceled) ||
+		errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.EPIPE) {
+		return true
+	}
+	var opErr *net.OpError
+	if errors.As(err, &opErr) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// sleep waits for d or until ctx is done, whichever comes first.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,160 @@
+package pexels_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/j-mnr/pexels-go"
+	"github.com/j-mnr/pexels-go/pexelstest"
+	"github.com/matryer/is"
+)
+
+func TestRetry(t *testing.T) {
+	// A backoff no test can sit through, so passing cases prove the wait came
+	// from the server's headers.
+	const never = time.Hour
+	unix := func(d time.Duration) string {
+		return strconv.FormatInt(time.Now().Add(d).Unix(), 10)
+	}
+	tests := map[string]struct {
+		status       int
+		header       http.Header
+		failures     int
+		policy       pexels.RetryPolicy
+		wantRequests int
+		wantStatus   int // zero when the request succeeds
+		minWait      time.Duration
+	}{
+		"Retry-After seconds": {
+			status:       http.StatusServiceUnavailable,
+			header:       http.Header{"Retry-After": {"1"}},
+			failures:     1,
+			policy:       pexels.RetryPolicy{MaxAttempts: 2, BaseBackoff: never},
+			wantRequests: 2,
+			minWait:      time.Second,
+		},
+		"Retry-After HTTP-date": {
+			status: http.StatusServiceUnavailable,
+			header: http.Header{"Retry-After": {
+				time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat),
+			}},
+			failures:     1,
+			policy:       pexels.RetryPolicy{MaxAttempts: 2, BaseBackoff: never},
+			wantRequests: 2,
+		},
+		"X-Ratelimit-Reset on 429": {
+			status: http.StatusTooManyRequests,
+			header: http.Header{
+				"X-Ratelimit-Remaining": {"0"},
+				"X-Ratelimit-Reset":     {unix(0)},
+			},
+			failures:     1,
+			policy:       pexels.RetryPolicy{MaxAttempts: 2, BaseBackoff: never},
+			wantRequests: 2,
+		},
+		"X-Ratelimit-Reset with quota left": {
+			status: http.StatusTooManyRequests,
+			header: http.Header{
+				"X-Ratelimit-Remaining": {"5"},
+				"X-Ratelimit-Reset":     {unix(time.Hour)},
+			},
+			failures: 1,
+			policy: pexels.RetryPolicy{
+				MaxAttempts: 2, BaseBackoff: time.Millisecond,
+				MaxBackoff: time.Second,
+			},
+			wantRequests: 2,
+		},
+		"Retry-After over MaxBackoff": {
+			status:   http.StatusServiceUnavailable,
+			header:   http.Header{"Retry-After": {"60"}},
+			failures: 1,
+			policy: pexels.RetryPolicy{
+				MaxAttempts: 3, BaseBackoff: time.Millisecond,
+				MaxBackoff: time.Second,
+			},
+			wantRequests: 1,
+			wantStatus:   http.StatusServiceUnavailable,
+		},
+		"X-Ratelimit-Reset over MaxBackoff": {
+			status: http.StatusTooManyRequests,
+			header: http.Header{
+				"X-Ratelimit-Remaining": {"0"},
+				"X-Ratelimit-Reset":     {unix(time.Hour)},
+			},
+			failures: 1,
+			policy: pexels.RetryPolicy{
+				MaxAttempts: 3, BaseBackoff: time.Millisecond,
+				MaxBackoff: time.Second,
+			},
+			wantRequests: 1,
+			wantStatus:   http.StatusTooManyRequests,
+		},
+		"attempts used up": {
+			status:       http.StatusBadGateway,
+			failures:     3,
+			policy:       pexels.RetryPolicy{MaxAttempts: 3, BaseBackoff: time.Millisecond},
+			wantRequests: 3,
+			wantStatus:   http.StatusBadGateway,
+		},
+		"not retryable": {
+			status:       http.StatusNotImplemented,
+			failures:     1,
+			policy:       pexels.RetryPolicy{MaxAttempts: 3, BaseBackoff: time.Millisecond},
+			wantRequests: 1,
+			wantStatus:   http.StatusNotImplemented,
+		},
+	}
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+			srv := pexelstest.NewServer()
+			defer srv.Close()
+			c, err := srv.NewClient(pexels.WithRetry(tc.policy))
+			is.NoErr(err)
+			srv.FailNextWith(tc.failures, tc.status, tc.header)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			start := time.Now()
+			_, err = c.GetPhoto(ctx, srv.Photos[0].ID)
+			is.True(time.Since(start) >= tc.minWait)
+			is.Equal(srv.Requests(), tc.wantRequests)
+			if tc.wantStatus == 0 {
+				is.NoErr(err)
+				return
+			}
+			var apiErr *pexels.APIError
+			is.True(errors.As(err, &apiErr))
+			is.Equal(apiErr.Common.StatusCode, tc.wantStatus)
+		})
+	}
+}
+
+func TestRetryCanceledBetweenAttempts(t *testing.T) {
+	is := is.New(t)
+	srv := pexelstest.NewServer()
+	defer srv.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c, err := srv.NewClient(
+		pexels.WithRetry(pexels.RetryPolicy{MaxAttempts: 3, BaseBackoff: time.Hour}),
+		pexels.WithHooks(pexels.Hooks{
+			After: func(context.Context, pexels.ResponseInfo) { cancel() },
+		}),
+	)
+	is.NoErr(err)
+	srv.FailNext(1, http.StatusServiceUnavailable)
+
+	start := time.Now()
+	_, err = c.GetPhoto(ctx, srv.Photos[0].ID)
+	is.True(errors.Is(err, context.Canceled))
+	is.True(time.Since(start) < 10*time.Second)
+	is.Equal(srv.Requests(), 1)
+}
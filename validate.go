@@ -0,0 +1,53 @@
+package pexels
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrInvalidParams = errors.New("invalid params")
+
+// maxPerPage is the most results Pexels returns in a single page.
+const maxPerPage = 80
+
+// FieldError is a single field of a params struct holding a value Pexels would
+// reject. Every FieldError matches ErrInvalidParams with errors.Is, as well as
+// the error it wraps.
+type FieldError struct {
+	Field string
+	Value any
+	Err   error
+}
+
+func (e *FieldError) Error() string {
+	if s, ok := e.Value.(string); ok {
+		return fmt.Sprintf("pexels: invalid %s %q: %v", e.Field, s, e.Err)
+	}
+	return fmt.Sprintf("pexels: invalid %s %v: %v", e.Field, e.Value, e.Err)
+}
+
+func (e *FieldError) Unwrap() error { return e.Err }
+
+func (e *FieldError) Is(target error) bool { return target == ErrInvalidParams }
+
+// fieldErrors collects every FieldError of a params struct.
+type fieldErrors []error
+
+func (fe *fieldErrors) add(field string, value any, err error) {
+	*fe = append(*fe, &FieldError{Field: field, Value: value, Err: err})
+}
+
+func (fe *fieldErrors) addf(field string, value any, format string, a ...any) {
+	fe.add(field, value, fmt.Errorf(format, a...))
+}
+
+func (fe *fieldErrors) checkPerPage(perPage uint8) {
+	if perPage > maxPerPage {
+		fe.addf("PerPage", perPage, "must be at most %d", maxPerPage)
+	}
+}
+
+// err joins all of the collected errors, it is nil if there were none.
+func (fe fieldErrors) err() error {
+	return errors.Join(fe...)
+}
@@ -0,0 +1,176 @@
+package pexels_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/j-mnr/pexels-go"
+	"github.com/j-mnr/pexels-go/pexelstest"
+	"github.com/matryer/is"
+)
+
+type validateTest[P any] struct {
+	params     P
+	wantFields []string // nil when the params are valid
+	wantErr    error    // the sentinel of a missing required field
+}
+
+// runValidateTests checks that Validate reports every bad field of the params
+// at once and that call, which sends them, rejects them without a request.
+func runValidateTests[P interface{ Validate() error }](
+	t *testing.T, call func(context.Context, *pexels.Client, P) error,
+	tests map[string]validateTest[P],
+) {
+	t.Helper()
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			is := is.New(t)
+			srv := pexelstest.NewServer()
+			defer srv.Close()
+			c, err := srv.NewClient()
+			is.NoErr(err)
+
+			err = tc.params.Validate()
+			callErr := call(context.Background(), c, tc.params)
+			if tc.wantFields == nil {
+				is.NoErr(err)
+				is.NoErr(callErr)
+				return
+			}
+			is.True(errors.Is(err, pexels.ErrInvalidParams))
+			is.True(errors.Is(callErr, pexels.ErrInvalidParams))
+			is.Equal(srv.Requests(), 0)
+			if tc.wantErr != nil {
+				is.True(errors.Is(err, tc.wantErr))
+			}
+
+			errs := []error{err}
+			if joined, ok := err.(interface{ Unwrap() []error }); ok {
+				errs = joined.Unwrap()
+			}
+			var fields []string
+			for _, err := range errs {
+				var fieldErr *pexels.FieldError
+				is.True(errors.As(err, &fieldErr))
+				is.True(errors.Is(fieldErr, pexels.ErrInvalidParams))
+				fields = append(fields, fieldErr.Field)
+			}
+			is.Equal(fields, tc.wantFields)
+		})
+	}
+}
+
+func TestCuratedPhotosParamsValidate(t *testing.T) {
+	runValidateTests(t,
+		func(ctx context.Context, c *pexels.Client, p *pexels.CuratedPhotosParams) error {
+			_, err := c.GetCuratedPhotos(ctx, p)
+			return err
+		},
+		map[string]validateTest[*pexels.CuratedPhotosParams]{
+			"nil":          {params: nil},
+			"max per page": {params: &pexels.CuratedPhotosParams{PerPage: 80}},
+			"per page": {
+				params:     &pexels.CuratedPhotosParams{PerPage: 81},
+				wantFields: []string{"PerPage"},
+			},
+		})
+}
+
+func TestPhotoSearchParamsValidate(t *testing.T) {
+	runValidateTests(t,
+		func(ctx context.Context, c *pexels.Client, p *pexels.PhotoSearchParams) error {
+			_, err := c.SearchPhotos(ctx, p)
+			return err
+		},
+		map[string]validateTest[*pexels.PhotoSearchParams]{
+			"valid": {params: &pexels.PhotoSearchParams{Query: "ocean"}},
+			"nil": {
+				params:     nil,
+				wantFields: []string{"Query"},
+				wantErr:    pexels.ErrMissingQuery,
+			},
+			"query and per page": {
+				params:     &pexels.PhotoSearchParams{PerPage: 81},
+				wantFields: []string{"Query", "PerPage"},
+				wantErr:    pexels.ErrMissingQuery,
+			},
+		})
+}
+
+func TestVideoSearchParamsValidate(t *testing.T) {
+	runValidateTests(t,
+		func(ctx context.Context, c *pexels.Client, p *pexels.VideoSearchParams) error {
+			_, err := c.SearchVideos(ctx, p)
+			return err
+		},
+		map[string]validateTest[*pexels.VideoSearchParams]{
+			"valid": {params: &pexels.VideoSearchParams{Query: "ocean"}},
+			"nil": {
+				params:     nil,
+				wantFields: []string{"Query"},
+				wantErr:    pexels.ErrMissingQuery,
+			},
+			"query and per page": {
+				params:     &pexels.VideoSearchParams{PerPage: 255},
+				wantFields: []string{"Query", "PerPage"},
+				wantErr:    pexels.ErrMissingQuery,
+			},
+		})
+}
+
+func TestPopularVideoParamsValidate(t *testing.T) {
+	runValidateTests(t,
+		func(ctx context.Context, c *pexels.Client, p *pexels.PopularVideoParams) error {
+			_, err := c.GetPopularVideos(ctx, p)
+			return err
+		},
+		map[string]validateTest[*pexels.PopularVideoParams]{
+			"nil":                 {params: nil},
+			"no maximum duration": {params: &pexels.PopularVideoParams{MinDuration: 10}},
+			"durations and per page": {
+				params: &pexels.PopularVideoParams{
+					MinDuration: 10, MaxDuration: 5, PerPage: 90,
+				},
+				wantFields: []string{"MinDuration", "PerPage"},
+			},
+		})
+}
+
+func TestCollectionParamsValidate(t *testing.T) {
+	runValidateTests(t,
+		func(ctx context.Context, c *pexels.Client, p *pexels.CollectionParams) error {
+			_, err := c.GetCollections(ctx, p)
+			return err
+		},
+		map[string]validateTest[*pexels.CollectionParams]{
+			"nil":   {params: nil},
+			"valid": {params: &pexels.CollectionParams{Page: 2, PerPage: 80}},
+			"per page": {
+				params:     &pexels.CollectionParams{PerPage: 200},
+				wantFields: []string{"PerPage"},
+			},
+		})
+}
+
+func TestCollectionMediaParamsValidate(t *testing.T) {
+	runValidateTests(t,
+		func(ctx context.Context, c *pexels.Client, p *pexels.CollectionMediaParams) error {
+			_, err := c.GetCollection(ctx, p)
+			return err
+		},
+		map[string]validateTest[*pexels.CollectionMediaParams]{
+			"valid": {params: &pexels.CollectionMediaParams{ID: "col0001", Type: "videos"}},
+			"nil": {
+				params:     nil,
+				wantFields: []string{"ID"},
+				wantErr:    pexels.ErrMissingCollectionID,
+			},
+			"ID, type and per page": {
+				params:     &pexels.CollectionMediaParams{Type: "gifs", PerPage: 81},
+				wantFields: []string{"ID", "Type", "PerPage"},
+				wantErr:    pexels.ErrMissingCollectionID,
+			},
+		})
+}
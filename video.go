@@ -1,8 +1,10 @@
 package pexels
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"net/url"
 )
 
 var ErrMissingQuery = errors.New("query is required")
@@ -83,30 +85,87 @@ type VideosResponse struct {
 // PopularVideoParams is parameters that can be selected for when searching for
 // specific popular videos on pexels.
 type PopularVideoParams struct {
-	MinWidth    uint16 `query:"min_width"`
-	MinHeight   uint16 `query:"min_height"`
-	MinDuration uint16 `query:"min_duration"` // In Seconds
-	MaxDuration uint16 `query:"max_duration"` // In Seconds
-	Page        uint16 `query:"page,1"`
-	PerPage     uint8  `query:"per_page,15"` // Max: 80
+	MinWidth    uint16
+	MinHeight   uint16
+	MinDuration uint16 // In Seconds
+	MaxDuration uint16 // In Seconds
+	Page        uint16 // Default: 1
+	PerPage     uint8  // Default: 15, Max: 80
+}
+
+// Encode returns the URL encoded query string of the params.
+func (p *PopularVideoParams) Encode() string {
+	if p == nil {
+		return ""
+	}
+	q := url.Values{}
+	setUint(q, "min_width", p.MinWidth, 0)
+	setUint(q, "min_height", p.MinHeight, 0)
+	setUint(q, "min_duration", p.MinDuration, 0)
+	setUint(q, "max_duration", p.MaxDuration, 0)
+	setUint(q, "page", p.Page, 1)
+	setUint(q, "per_page", p.PerPage, 15)
+	return q.Encode()
+}
+
+// Validate reports every field of the params Pexels would reject.
+func (p *PopularVideoParams) Validate() error {
+	if p == nil {
+		return nil
+	}
+	var fe fieldErrors
+	if p.MaxDuration != 0 && p.MinDuration > p.MaxDuration {
+		fe.addf("MinDuration", p.MinDuration,
+			"must not be greater than MaxDuration %d", p.MaxDuration)
+	}
+	fe.checkPerPage(p.PerPage)
+	return fe.err()
 }
 
 // VideoSearchParams requires Query. A Query allows you to search for any topic
 // that you would like to receive video information about.
 type VideoSearchParams struct {
 	// Query is required
-	Query string `query:"query"`
+	Query string
 
 	General
-	Page    uint16 `query:"page,1"`
-	PerPage uint8  `query:"per_page,15"` // Max: 80
+	Page    uint16 // Default: 1
+	PerPage uint8  // Default: 15, Max: 80
 }
 
-// GetVideo returns a Video based on its ID. It does not return an error if the
-// Video could not be found by its ID, only if something went wrong while
-// getting the resource.
-func (c *Client) GetVideo(videoID uint64) (VideoResponse, error) {
-	resp, err := get(*c, fmt.Sprint(videoEndpoint, videoID), "", &Video{})
+// Encode returns the URL encoded query string of the params.
+func (p *VideoSearchParams) Encode() string {
+	if p == nil {
+		return ""
+	}
+	q := url.Values{}
+	setString(q, "query", p.Query)
+	p.General.encode(q)
+	setUint(q, "page", p.Page, 1)
+	setUint(q, "per_page", p.PerPage, 15)
+	return q.Encode()
+}
+
+// Validate reports every field of the params Pexels would reject, including a
+// missing Query.
+func (p *VideoSearchParams) Validate() error {
+	if p == nil {
+		return &FieldError{Field: "Query", Value: "", Err: ErrMissingQuery}
+	}
+	var fe fieldErrors
+	if p.Query == "" {
+		fe.add("Query", p.Query, ErrMissingQuery)
+	}
+	fe.checkPerPage(p.PerPage)
+	return fe.err()
+}
+
+// GetVideo returns a Video based on its ID. If the Video could not be found
+// by its ID the returned error matches ErrNotFound.
+func (c *Client) GetVideo(
+	ctx context.Context, videoID uint64,
+) (VideoResponse, error) {
+	resp, err := get(ctx, *c, fmt.Sprint(videoEndpoint, videoID), nil, &Video{})
 	if err != nil {
 		return VideoResponse{}, err
 	}
@@ -117,9 +176,12 @@ func (c *Client) GetVideo(videoID uint64) (VideoResponse, error) {
 
 // GetPopularVideos returns the current popular pexels videos.
 func (c *Client) GetPopularVideos(
-	pvp *PopularVideoParams,
+	ctx context.Context, pvp *PopularVideoParams,
 ) (VideosResponse, error) {
-	resp, err := get(*c, popularVideosEndpoint, pvp, &VideoPayload{})
+	if err := pvp.Validate(); err != nil {
+		return VideosResponse{}, err
+	}
+	resp, err := get(ctx, *c, popularVideosEndpoint, pvp, &VideoPayload{})
 	if err != nil {
 		return VideosResponse{}, err
 	}
@@ -131,12 +193,12 @@ func (c *Client) GetPopularVideos(
 // SearchVideos enables you to search the entire pexels database for any
 // subject that you would like and receive videos on that subject.
 func (c *Client) SearchVideos(
-	vsp *VideoSearchParams,
+	ctx context.Context, vsp *VideoSearchParams,
 ) (VideosResponse, error) {
-	if vsp == nil || vsp.Query == "" {
-		return VideosResponse{}, ErrMissingQuery
+	if err := vsp.Validate(); err != nil {
+		return VideosResponse{}, err
 	}
-	resp, err := get(*c, searchVideosEndpoint, vsp, &VideoPayload{})
+	resp, err := get(ctx, *c, searchVideosEndpoint, vsp, &VideoPayload{})
 	if err != nil {
 		return VideosResponse{}, err
 	}
@@ -0,0 +1,143 @@
+package pexels
+
+import (
+	"math"
+	"sort"
+)
+
+// The qualities found in VideoFile.Quality.
+const (
+	QualitySD  = "sd"
+	QualityHD  = "hd"
+	QualityUHD = "uhd"
+)
+
+// defaultAspectTolerance is how far off the aspect ratio of a VideoFile may be
+// when FileConstraints.AspectTolerance is not set; enough to let 1366x768 pass
+// for 16:9.
+const defaultAspectTolerance = 0.01
+
+// FileConstraints narrows down the VideoFile picked by Video.BestFile. Every
+// zero field is not taken into account.
+type FileConstraints struct {
+	MinWidth  uint16
+	MinHeight uint16
+	MaxWidth  uint16
+	MaxHeight uint16
+	// Qualities lists the accepted qualities, most preferred first. A file of
+	// a preferred quality is picked over a bigger file of a lesser quality.
+	Qualities []string
+	// FileType is the accepted MIME type, e.g. "video/mp4".
+	FileType string
+	// AspectRatio is the accepted width divided by height, e.g. 16.0 / 9.
+	AspectRatio float64
+	// AspectTolerance is how much the aspect ratio of a file may differ
+	// relatively from AspectRatio. Default: 0.01
+	AspectTolerance float64
+}
+
+// BestFile returns the VideoFile that fits fc best: of the files meeting every
+// constraint it picks the most preferred quality, then the highest resolution
+// and finally the lowest ID, so the same file is always picked. It returns
+// false if no file meets the constraints.
+func (v Video) BestFile(fc FileConstraints) (VideoFile, bool) {
+	var best VideoFile
+	found := false
+	for _, f := range v.VideoFiles {
+		if !fc.allows(f) {
+			continue
+		}
+		if !found || fc.better(f, best) {
+			best, found = f, true
+		}
+	}
+	return best, found
+}
+
+// FilesByResolution returns a copy of the VideoFiles sorted with
+// SortFilesByResolution.
+func (v Video) FilesByResolution() []VideoFile {
+	files := make([]VideoFile, len(v.VideoFiles))
+	copy(files, v.VideoFiles)
+	SortFilesByResolution(files)
+	return files
+}
+
+// SortFilesByResolution sorts files from the highest resolution to the lowest.
+// Files of the same resolution are sorted from the best quality, uhd then hd
+// then sd, and then by ID.
+func SortFilesByResolution(files []VideoFile) {
+	sort.Slice(files, func(i, j int) bool {
+		return sortsBefore(files[i], files[j])
+	})
+}
+
+// sortsBefore orders files by resolution, then quality, then ID.
+func sortsBefore(a, b VideoFile) bool {
+	if pa, pb := pixels(a), pixels(b); pa != pb {
+		return pa > pb
+	}
+	if qa, qb := qualityRank(a.Quality), qualityRank(b.Quality); qa != qb {
+		return qa > qb
+	}
+	return a.ID < b.ID
+}
+
+func (fc FileConstraints) allows(f VideoFile) bool {
+	switch {
+	case f.Width < fc.MinWidth, f.Height < fc.MinHeight,
+		fc.MaxWidth != 0 && f.Width > fc.MaxWidth,
+		fc.MaxHeight != 0 && f.Height > fc.MaxHeight,
+		fc.FileType != "" && f.FileType != fc.FileType,
+		len(fc.Qualities) > 0 && fc.preference(f.Quality) < 0:
+		return false
+	}
+	if fc.AspectRatio == 0 {
+		return true
+	}
+	if f.Height == 0 {
+		return false
+	}
+	tolerance := fc.AspectTolerance
+	if tolerance == 0 {
+		tolerance = defaultAspectTolerance
+	}
+	ratio := float64(f.Width) / float64(f.Height)
+	return math.Abs(ratio-fc.AspectRatio)/fc.AspectRatio <= tolerance
+}
+
+// better reports whether a is a better fit than b, both having passed allows.
+func (fc FileConstraints) better(a, b VideoFile) bool {
+	if len(fc.Qualities) > 0 {
+		if pa, pb := fc.preference(a.Quality), fc.preference(b.Quality); pa != pb {
+			return pa < pb
+		}
+	}
+	return sortsBefore(a, b)
+}
+
+// preference is the index of quality in Qualities, or -1 if it is not there.
+func (fc FileConstraints) preference(quality string) int {
+	for i, q := range fc.Qualities {
+		if q == quality {
+			return i
+		}
+	}
+	return -1
+}
+
+func pixels(f VideoFile) int {
+	return int(f.Width) * int(f.Height)
+}
+
+func qualityRank(quality string) int {
+	switch quality {
+	case QualityUHD:
+		return 3
+	case QualityHD:
+		return 2
+	case QualitySD:
+		return 1
+	}
+	return 0
+}